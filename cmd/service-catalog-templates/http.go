@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/golang/glog"
+
+	"github.com/Azure/service-catalog-templates/pkg/controller"
+	"github.com/Azure/service-catalog-templates/pkg/metrics"
+)
+
+// healthChecks backs the liveness and readiness probes. The controller is
+// only set once the informer caches have synced.
+type healthChecks struct {
+	mu         sync.RWMutex
+	controller *controller.Controller
+}
+
+func (h *healthChecks) setController(c *controller.Controller) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.controller = c
+}
+
+// live reports if the process should be restarted.
+func (h *healthChecks) live() error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.controller == nil {
+		return nil
+	}
+	return h.controller.Healthy()
+}
+
+// ready reports if the caches have synced and the workers are healthy.
+func (h *healthChecks) ready() error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.controller == nil {
+		return errors.New("the informer caches have not synced")
+	}
+	return h.controller.Healthy()
+}
+
+// serveHTTP serves the metrics and the health probes.
+func serveHTTP(address string, health *healthChecks) {
+	if address == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.HandleFunc("/healthz", probeHandler(health.live))
+	mux.HandleFunc("/readyz", probeHandler(health.ready))
+
+	glog.Infof("Serving metrics and health probes on %s", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		glog.Fatalf("Error serving metrics and health probes: %s", err.Error())
+	}
+}
+
+func probeHandler(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := check(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}
+}
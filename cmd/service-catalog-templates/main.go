@@ -4,7 +4,6 @@
 package main
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -14,23 +13,46 @@ import (
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-sdk"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
 	"github.com/golang/glog"
+	"github.com/satori/go.uuid"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coreinformers "k8s.io/client-go/informers"
 	coreclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
 
 	clientset "github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned"
 	informers "github.com/Azure/service-catalog-templates/pkg/client/informers/externalversions"
 	"github.com/Azure/service-catalog-templates/pkg/controller"
+	"github.com/Azure/service-catalog-templates/pkg/metrics"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
 	"github.com/Azure/service-catalog-templates/pkg/signals"
 	svcatclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
 	svcatinformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
 )
 
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
 var (
-	masterURL  string
-	kubeconfig string
+	masterURL               string
+	kubeconfig              string
+	listenAddress           string
+	leaderElect             bool
+	leaderElectionNamespace string
+	leaderElectionID        string
+	configFile              string
+
+	controllerConfig = controller.NewConfig()
 )
 
 func main() {
@@ -59,41 +81,154 @@ func main() {
 		glog.Fatalf("Error building example clientset: %s", err.Error())
 	}
 
-	duration := time.Second * 30
-	coreInformerFactory := coreinformers.NewSharedInformerFactory(coreClient, duration)
-	svcatInformerFactory := svcatinformers.NewSharedInformerFactory(svcatClient, duration)
-	templatesInformerFactory := informers.NewSharedInformerFactory(templatesClient, duration)
-
-	coreSDK := coresdk.New(coreClient, coreInformerFactory)
+	// Limit the informers to the watched namespaces
+	resync := controllerConfig.ResyncPeriod.Duration
+	namespace := controllerConfig.InformerNamespace()
+	coreInformerFactory := coreinformers.NewFilteredSharedInformerFactory(coreClient, resync, namespace, func(options *metav1.ListOptions) {
+		controllerConfig.TweakListOptions(options)
+		// Only cache the secrets managed by the Templates controller
+		options.LabelSelector = builder.ManagedSecretSelector()
+	})
+	workloadInformerFactory := coreinformers.NewFilteredSharedInformerFactory(coreClient, resync, namespace, func(options *metav1.ListOptions) {
+		controllerConfig.TweakListOptions(options)
+		// Only cache the workloads of container provided instances
+		options.LabelSelector = builder.ManagedWorkloadSelector()
+	})
+	// Namespaces are cluster scoped, they are cached for the provider selected by their label
+	namespaceInformerFactory := coreinformers.NewSharedInformerFactory(coreClient, resync)
+	// The service catalog API server may not support field selectors on the namespace,
+	// so excluded namespaces are filtered by the event handlers instead
+	svcatInformerFactory := svcatinformers.NewFilteredSharedInformerFactory(svcatClient, resync, namespace, nil)
+	templatesInformerFactory := informers.NewFilteredSharedInformerFactory(templatesClient, resync, namespace, controllerConfig.TweakListOptions)
+
+	coreSDK := coresdk.New(coreClient, coreInformerFactory, workloadInformerFactory, namespaceInformerFactory)
 	svcatSDK := servicecatalogsdk.New(svcatClient, svcatInformerFactory)
 	templateSDK := servicecatalogtempltesdk.New(templatesClient, templatesInformerFactory, svcatSDK)
 
+	health := &healthChecks{}
+	go serveHTTP(listenAddress, health)
+
 	// Wait for the caches to be synced before starting
 	glog.Info("Initializing...")
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-	initG, ctx := errgroup.WithContext(ctx)
+	var initG errgroup.Group
 	initG.Go(func() error { return coreSDK.Init(stopCh) })
 	initG.Go(func() error { return svcatSDK.Init(stopCh) })
 	initG.Go(func() error { return templateSDK.Init(stopCh) })
-	if err := initG.Wait(); err != nil {
+	if err := waitForInit(&initG, controllerConfig.CacheSyncTimeout.Duration); err != nil {
 		glog.Fatalf("Error initializing informer caches: %s", err)
 	}
 
-	controller := controller.NewController(coreSDK, templateSDK, svcatSDK)
+	controller := controller.NewController(controllerConfig, coreSDK, templateSDK, svcatSDK)
+	metrics.RegisterTemplatedInstanceCollector(templateSDK.InstanceCache())
+	health.setController(controller)
+
+	run := func(<-chan struct{}) {
+		if err := controller.Run(stopCh); err != nil {
+			glog.Fatalf("Error running controller: %s", err.Error())
+		}
+	}
+
+	if !leaderElect {
+		run(stopCh)
+		return
+	}
+
+	// Only the elected replica runs the workers. The others keep their caches
+	// warm so that they can take over as soon as the lease expires.
+	lock, err := newResourceLock(coreClient)
+	if err != nil {
+		glog.Fatalf("Error creating leader election lock: %s", err.Error())
+	}
+	go leaderelection.RunOrDie(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: run,
+			OnStoppedLeading: func() {
+				glog.Fatalf("Lost leadership, exiting so that another replica can take over")
+			},
+			OnNewLeader: func(identity string) {
+				glog.Infof("%s is the leader", identity)
+			},
+		},
+	})
+
+	<-stopCh
+}
+
+// waitForInit waits for the informer caches to sync, giving up after the timeout.
+// A timeout of zero waits indefinitely.
+func waitForInit(initG *errgroup.Group, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() { done <- initG.Wait() }()
+
+	if timeout == 0 {
+		return <-done
+	}
 
-	if err = controller.Run(2, stopCh); err != nil {
-		glog.Fatalf("Error running controller: %s", err.Error())
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for the caches to sync", timeout)
 	}
 }
 
+func newResourceLock(coreClient coreclient.Interface) (resourcelock.Interface, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	identity := hostname + "_" + uuid.NewV4().String()
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartLogging(glog.Infof)
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: coreClient.CoreV1().Events(leaderElectionNamespace)})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: leaderElectionID})
+
+	return resourcelock.New(resourcelock.ConfigMapsResourceLock, leaderElectionNamespace, leaderElectionID,
+		coreClient.CoreV1(), resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: recorder,
+		})
+}
+
 func init() {
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&configFile, "config", "", "Path to a YAML config file. Flags override the values in the file.")
+	controllerConfig.AddFlags(flag.CommandLine)
+	flag.StringVar(&listenAddress, "listen-address", ":8080", "The address on which to serve /metrics, /healthz and /readyz. Set to an empty string to disable.")
+	flag.BoolVar(&leaderElect, "leader-elect", true, "Elect a leader before running the workers, so that multiple replicas can be run.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "", "The namespace of the leader election lock. Defaults to $POD_NAMESPACE, or default when unset.")
+	flag.StringVar(&leaderElectionID, "leader-election-id", "service-catalog-templates-controller", "The name of the leader election lock.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 }
 
 func configure() {
 	flag.Parse()
 
+	if configFile != "" {
+		// Remember the flags that were set, so that they take precedence over the config file
+		setFlags := map[string]string{}
+		flag.Visit(func(f *flag.Flag) {
+			setFlags[f.Name] = f.Value.String()
+		})
+
+		if err := controllerConfig.LoadFile(configFile); err != nil {
+			glog.Fatal(err)
+		}
+
+		for name, value := range setFlags {
+			flag.Set(name, value)
+		}
+	}
+
+	if err := controllerConfig.Validate(); err != nil {
+		glog.Fatalf("Invalid configuration: %s", err)
+	}
+
 	if kubeconfig == "" {
 		kubeconfig = os.Getenv("KUBECONFIG")
 		if kubeconfig == "" {
@@ -104,6 +239,13 @@ func configure() {
 		}
 	}
 
+	if leaderElectionNamespace == "" {
+		leaderElectionNamespace = os.Getenv("POD_NAMESPACE")
+		if leaderElectionNamespace == "" {
+			leaderElectionNamespace = "default"
+		}
+	}
+
 	if kubeconfig == "" {
 		glog.Infof("Using kubeconfig: %s", kubeconfig)
 	} else {
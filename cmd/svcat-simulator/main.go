@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// svcat-simulator replaces the service catalog controller and its brokers,
+// so that the Templates controller and svcatt can be exercised end to end
+// on a local cluster without provisioning real services.
+package main
+
+import (
+	"flag"
+
+	"github.com/golang/glog"
+	coreclient "k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/Azure/service-catalog-templates/pkg/signals"
+	"github.com/Azure/service-catalog-templates/pkg/simulator"
+	svcatclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+)
+
+var (
+	masterURL  string
+	kubeconfig string
+
+	simulatorConfig = simulator.NewConfig()
+)
+
+func main() {
+	flag.Parse()
+	if err := simulatorConfig.Validate(); err != nil {
+		glog.Fatalf("Invalid configuration: %s", err)
+	}
+
+	// set up signals so we handle the first shutdown signal gracefully
+	stopCh := signals.SetupSignalHandler()
+
+	cfg, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
+	if err != nil {
+		glog.Fatalf("Error building kubeconfig: %s", err.Error())
+	}
+
+	coreClient, err := coreclient.NewForConfig(cfg)
+	if err != nil {
+		glog.Fatalf("Error building kubernetes clientset: %s", err.Error())
+	}
+
+	svcatClient, err := svcatclientset.NewForConfig(cfg)
+	if err != nil {
+		glog.Fatalf("Error building service catalog clientset: %s", err.Error())
+	}
+
+	sim := simulator.New(simulatorConfig, coreClient, svcatClient)
+	if err := sim.Run(stopCh); err != nil {
+		glog.Fatalf("Error running simulator: %s", err.Error())
+	}
+}
+
+func init() {
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	simulatorConfig.AddFlags(flag.CommandLine)
+}
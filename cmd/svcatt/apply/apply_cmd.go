@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package apply
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type applyCmd struct {
+	*svcattcommand.Context
+	ns        string
+	filenames []string
+	applySet  string
+	prune     bool
+	dryRun    bool
+}
+
+// NewApplyCmd builds a "svcatt apply" command
+func NewApplyCmd(cxt *svcattcommand.Context) *cobra.Command {
+	applyCmd := &applyCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update the templates and templated resources defined in local files",
+		Long: `Create or update the templates and templated resources defined in local files.
+
+The changes are printed before they are made. A resource is updated with the spec,
+labels and annotations of its manifest, and apply stops when a manifest specifies a
+resource version that is out of date.
+
+With --apply-set, the templated instances and bindings are labeled with the name of
+the set. --prune then deletes the templated resources of the set, in any namespace,
+that are no longer defined in the files. Templates are never pruned.`,
+		Example: `
+  svcatt apply -f templates/ -f app.yaml
+  svcatt apply -f app/ --apply-set wordpress --prune --dry-run
+  svcatt apply -f app/ --apply-set wordpress --prune
+`,
+		PreRunE: command.PreRunE(applyCmd),
+		RunE:    command.RunE(applyCmd),
+	}
+	cmd.Flags().StringSliceVarP(&applyCmd.filenames, "filename", "f", nil,
+		"File or directory containing templates and templated resources. May be specified multiple times.")
+	cmd.Flags().StringVarP(
+		&applyCmd.ns,
+		"namespace",
+		"n",
+		"",
+		"The namespace of resources that do not specify one",
+	)
+	cmd.Flags().StringVar(&applyCmd.applySet, "apply-set", "",
+		"Label the templated resources with the name of an apply set")
+	cmd.Flags().BoolVar(&applyCmd.prune, "prune", false,
+		"Delete the templated resources of the apply set that are not defined in the files")
+	cmd.Flags().BoolVar(&applyCmd.dryRun, "dry-run", false,
+		"Print the changes without making them")
+
+	return cmd
+}
+
+func (c *applyCmd) Validate(args []string) error {
+	if len(c.filenames) == 0 {
+		return fmt.Errorf("at least one --filename is required")
+	}
+	if c.prune && c.applySet == "" {
+		return fmt.Errorf("--prune requires --apply-set")
+	}
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+	return nil
+}
+
+func (c *applyCmd) Run() error {
+	objects, err := svcatt.LoadManifests(c.filenames, c.ns)
+	if err != nil {
+		return err
+	}
+
+	changes, err := c.App().PlanApply(objects, svcatt.ApplyOptions{ApplySet: c.applySet, Prune: c.prune})
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteApplyPlan(c.Output, changes)
+	if c.dryRun || len(changes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(c.Output)
+	return c.App().Apply(changes, func(change svcatt.ApplyChange) {
+		svcattoutput.WriteAppliedChange(c.Output, change)
+	})
+}
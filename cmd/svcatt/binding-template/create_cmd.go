@@ -0,0 +1,88 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package bindingtemplate
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type createCmd struct {
+	*svcattcommand.Context
+	svcattcommand.ScopeFlags
+	specFlags
+
+	cmd        *cobra.Command
+	name       string
+	brokerName string
+}
+
+// NewCreateCmd builds a "svcat create binding-template" command
+func NewCreateCmd(cxt *svcattcommand.Context) *cobra.Command {
+	createCmd := &createCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "binding-template NAME --type SERVICE_TYPE",
+		Aliases: []string{"bindingtemplate", "bndt"},
+		Short:   "Create a binding template for a service type",
+		Long: `Create a binding template for a service type.
+
+The template is labeled with its service type. A broker template requires
+--broker-name.`,
+		Example: `
+  svcat create binding-template mysqldb --type mysqldb --secret-key host=MYSQL_HOST
+  svcat create binding-template mysqldb --cluster --type mysqldb -p sslMode=require
+  svcat create binding-template mysqldb --broker --broker-name osba --type mysqldb
+`,
+		PreRunE: command.PreRunE(createCmd),
+		RunE:    command.RunE(createCmd),
+	}
+	createCmd.cmd = cmd
+	svcattcommand.AddScopeFlags(cmd.Flags(), &createCmd.ScopeFlags)
+	createCmd.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&createCmd.brokerName, "broker-name", "",
+		"The broker that a broker-level template applies to, requires --broker")
+	return cmd
+}
+
+func (c *createCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a template name is required")
+	}
+	c.name = args[0]
+
+	if c.serviceType == "" {
+		return fmt.Errorf("--type is required")
+	}
+	if c.brokerName != "" && !c.Broker {
+		return fmt.Errorf("--broker-name requires --broker")
+	}
+	if c.Broker && c.brokerName == "" {
+		return fmt.Errorf("--broker-name is required with --broker")
+	}
+
+	if err := c.ScopeFlags.Validate(c.App().CurrentNamespace); err != nil {
+		return err
+	}
+	return c.validate()
+}
+
+func (c *createCmd) Run() error {
+	var spec templates.BindingTemplateSpec
+	c.apply(c.cmd.Flags(), &spec)
+
+	bndt := servicecatalogtempltesdk.BuildBindingTemplate(c.Scope(), c.Namespace, c.name, c.brokerName, spec)
+	bndt, err := c.App().CreateBindingTemplate(bndt)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteBindingTemplateDetails(c.Output, bndt)
+	return nil
+}
@@ -20,6 +20,8 @@ type describeCmd struct {
 	brokerLevel  bool
 	clusterLevel bool
 	serviceType  string
+	output       string
+	format       svcattoutput.Format
 }
 
 // NewDescribeCmd builds a "svcat describe binding-template" command
@@ -48,6 +50,7 @@ func NewDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
 		"List templates defined at the cluster-level")
 	cmd.Flags().StringVarP(&describeCmd.serviceType, "type", "t", "",
 		"Filter the templates by a service type")
+	svcattcommand.AddOutputFlag(cmd.Flags(), &describeCmd.output)
 	return cmd
 }
 
@@ -60,7 +63,10 @@ func (c *describeCmd) Validate(args []string) error {
 	if c.ns == "" {
 		c.ns = c.App().CurrentNamespace
 	}
-	return nil
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *describeCmd) Run() error {
@@ -73,6 +79,10 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
+	if !c.format.IsTable() {
+		return svcattoutput.WriteObject(c.Output, c.format, bndt)
+	}
+
 	svcattoutput.WriteBindingTemplateDetails(c.Output, bndt)
 
 	return nil
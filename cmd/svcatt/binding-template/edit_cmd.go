@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package bindingtemplate
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type editCmd struct {
+	*svcattcommand.Context
+	svcattcommand.ScopeFlags
+	specFlags
+
+	cmd  *cobra.Command
+	name string
+}
+
+// NewEditCmd builds a "svcat edit binding-template" command
+func NewEditCmd(cxt *svcattcommand.Context) *cobra.Command {
+	editCmd := &editCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "binding-template NAME",
+		Aliases: []string{"bindingtemplate", "bndt"},
+		Short:   "Change the fields of an binding template that are specified",
+		Long: `Change the fields of an binding template that are specified.
+
+Fields without a flag are left unchanged. --param, --params-json, --secret and
+--secret-key replace all the values of that kind.`,
+		Example: `
+  svcat edit binding-template mysqldb --secret-key host=DB_HOST --secret-key port=DB_PORT
+  svcat edit binding-template mysqldb --cluster -p sslMode=require
+`,
+		PreRunE: command.PreRunE(editCmd),
+		RunE:    command.RunE(editCmd),
+	}
+	editCmd.cmd = cmd
+	svcattcommand.AddScopeFlags(cmd.Flags(), &editCmd.ScopeFlags)
+	editCmd.addFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *editCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a template name is required")
+	}
+	c.name = args[0]
+
+	if c.cmd.Flags().Changed("type") && c.serviceType == "" {
+		return fmt.Errorf("--type cannot be empty")
+	}
+
+	if err := c.ScopeFlags.Validate(c.App().CurrentNamespace); err != nil {
+		return err
+	}
+	return c.validate()
+}
+
+func (c *editCmd) Run() error {
+	bndt, err := c.App().GetBindingTemplate(c.Namespace, c.Cluster, c.Broker, c.name)
+	if err != nil {
+		return err
+	}
+
+	c.apply(c.cmd.Flags(), bndt.GetBindingTemplateSpec())
+
+	bndt, err = c.App().UpdateBindingTemplate(bndt)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteBindingTemplateDetails(c.Output, bndt)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type getCmd struct {
@@ -18,6 +19,8 @@ type getCmd struct {
 	brokerLevel   bool
 	clusterLevel  bool
 	serviceType   string
+	output        string
+	format        svcattoutput.Format
 }
 
 // NewGetCmd builds a "svcat get binding-templates" command
@@ -32,6 +35,7 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
   svcat get binding-templates --cluster
   svcat get binding-templates --broker
   svcat get binding-templates --type mysqldb
+  svcat get binding-templates --cluster -o name
 `,
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -57,6 +61,8 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
 	cmd.Flags().StringVarP(&getCmd.serviceType, "type", "t", "",
 		"Filter the templates by a service type")
 
+	svcattcommand.AddOutputFlag(cmd.Flags(), &getCmd.output)
+
 	return cmd
 }
 
@@ -69,7 +75,9 @@ func (c *getCmd) Validate(args []string) error {
 		c.ns = c.App().CurrentNamespace
 	}
 
-	return nil
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *getCmd) Run() error {
@@ -90,6 +98,14 @@ func (c *getCmd) getAll() error {
 		return err
 	}
 
+	if !c.format.IsTable() {
+		objs := make([]runtime.Object, 0, len(bndts))
+		for _, bndt := range bndts {
+			objs = append(objs, bndt)
+		}
+		return svcattoutput.WriteObjectList(c.Output, c.format, objs...)
+	}
+
 	svcattoutput.WriteBindingTemplateList(c.Output, bndts...)
 	return nil
 }
@@ -100,6 +116,10 @@ func (c *getCmd) get() error {
 		return err
 	}
 
+	if !c.format.IsTable() {
+		return svcattoutput.WriteObject(c.Output, c.format, bndt)
+	}
+
 	svcattoutput.WriteBindingTemplateList(c.Output, bndt)
 	return nil
 }
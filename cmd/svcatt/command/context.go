@@ -9,6 +9,9 @@ import (
 	"github.com/spf13/viper"
 )
 
+// OfflineAnnotation marks a command that does not need to connect to a cluster.
+const OfflineAnnotation = "svcatt/offline"
+
 // Context is ambient data necessary to run any svcatt command.
 type Context struct {
 	*svcatcommand.Context
@@ -0,0 +1,47 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattcommand
+
+import (
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/spf13/pflag"
+)
+
+// FilterFlags filter the templated resources listed by a get command.
+type FilterFlags struct {
+	Selector    string
+	ServiceType string
+	Status      string
+}
+
+// AddFilterFlags adds the --selector, --type and --status flags.
+func AddFilterFlags(flags *pflag.FlagSet, opts *FilterFlags) {
+	flags.StringVarP(&opts.Selector, "selector", "l", "",
+		"Label selector to filter on, e.g. app=wordpress")
+	flags.StringVar(&opts.ServiceType, "type", "",
+		"Only list resources of the service type")
+	flags.StringVar(&opts.Status, "status", "",
+		"Only list resources with the status, allowed values: ready, pending, failed")
+}
+
+// IsSet determines if any filter was specified.
+func (f *FilterFlags) IsSet() bool {
+	return f.Selector != "" || f.ServiceType != "" || f.Status != ""
+}
+
+// ListOptions validates the filters, and converts them to the options of a list.
+func (f *FilterFlags) ListOptions() (servicecatalogtempltesdk.TemplatedListOptions, error) {
+	opts := servicecatalogtempltesdk.TemplatedListOptions{
+		Selector:    f.Selector,
+		ServiceType: f.ServiceType,
+	}
+	if f.Status != "" {
+		status, err := servicecatalogtempltesdk.ParseStatus(f.Status)
+		if err != nil {
+			return opts, err
+		}
+		opts.Status = status
+	}
+	return opts, nil
+}
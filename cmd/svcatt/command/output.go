@@ -0,0 +1,19 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattcommand
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// AddOutputFlag adds the --output flag that selects a machine-readable output format.
+func AddOutputFlag(flags *pflag.FlagSet, output *string) {
+	flags.StringVarP(
+		output,
+		"output",
+		"o",
+		"",
+		"The output format: json, yaml, name, jsonpath=TEMPLATE or go-template=TEMPLATE. Defaults to a table",
+	)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattcommand
+
+import (
+	"fmt"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/spf13/pflag"
+)
+
+// ScopeFlags select the namespace, cluster or broker scope of a template.
+type ScopeFlags struct {
+	Namespace string
+	Cluster   bool
+	Broker    bool
+}
+
+// AddScopeFlags adds the --namespace, --cluster and --broker flags.
+func AddScopeFlags(flags *pflag.FlagSet, opts *ScopeFlags) {
+	flags.StringVarP(&opts.Namespace, "namespace", "n", "",
+		"The namespace of the template")
+	flags.BoolVarP(&opts.Cluster, "cluster", "c", false,
+		"The template is defined at the cluster-level")
+	flags.BoolVarP(&opts.Broker, "broker", "b", false,
+		"The template is defined at the broker-level")
+}
+
+// Validate checks that a single scope was selected, and defaults the namespace.
+func (f *ScopeFlags) Validate(currentNamespace string) error {
+	if f.Cluster && f.Broker {
+		return fmt.Errorf("--cluster cannot be used with --broker")
+	}
+	if f.Namespace == "" {
+		f.Namespace = currentNamespace
+	}
+	return nil
+}
+
+// Scope is the selected scope of the template.
+func (f *ScopeFlags) Scope() templates.TemplateScope {
+	switch {
+	case f.Broker:
+		return templates.ScopeBroker
+	case f.Cluster:
+		return templates.ScopeCluster
+	default:
+		return templates.ScopeNamespace
+	}
+}
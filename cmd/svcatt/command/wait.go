@@ -0,0 +1,43 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattcommand
+
+import (
+	"time"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/spf13/pflag"
+)
+
+// DefaultWaitTimeout is how long a command waits for its changes to complete by default.
+const DefaultWaitTimeout = 5 * time.Minute
+
+// WaitFlags are the flags of commands that can wait for their changes to complete.
+type WaitFlags struct {
+	Wait    bool
+	Timeout time.Duration
+}
+
+// AddWaitFlags adds the --wait and --timeout flags.
+func AddWaitFlags(flags *pflag.FlagSet, opts *WaitFlags) {
+	flags.BoolVar(&opts.Wait, "wait", false,
+		"Wait until the operation completes, printing its progress")
+	flags.DurationVar(&opts.Timeout, "timeout", DefaultWaitTimeout,
+		"How long to wait with --wait before giving up, e.g. 30s or 10m")
+}
+
+// WaitForTemplatedInstance waits for a templated instance to reach the condition, printing its progress.
+func (cxt *Context) WaitForTemplatedInstance(ns, name string, condition svcatt.WaitCondition, timeout time.Duration) error {
+	return cxt.App().WaitForTemplatedInstance(ns, name, condition, timeout, func(status string) {
+		svcattoutput.WriteWaitProgress(cxt.Output, "templated-instance", name, status)
+	})
+}
+
+// WaitForTemplatedBinding waits for a templated binding to reach the condition, printing its progress.
+func (cxt *Context) WaitForTemplatedBinding(ns, name string, condition svcatt.WaitCondition, timeout time.Duration) error {
+	return cxt.App().WaitForTemplatedBinding(ns, name, condition, timeout, func(status string) {
+		svcattoutput.WriteWaitProgress(cxt.Output, "templated-binding", name, status)
+	})
+}
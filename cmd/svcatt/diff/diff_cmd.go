@@ -0,0 +1,109 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package diff
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type diffCmd struct {
+	*svcattcommand.Context
+	ns        string
+	filenames []string
+	output    string
+	format    svcattoutput.Format
+}
+
+// NewDiffCmd builds a "svcatt diff" command
+func NewDiffCmd(cxt *svcattcommand.Context) *cobra.Command {
+	diffCmd := &diffCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Preview how instance templates defined in local files would change the templated instances in the cluster",
+		Long: `Preview how instance templates defined in local files would change the templated instances in the cluster.
+
+Every templated instance that the templates apply to is resolved twice, with the
+templates in the cluster and with the templates from the files replacing the
+templates of the same name. The changes to the plan and to the top-level
+parameters of each instance are printed.
+
+A change of plan is flagged when the instance was provisioned and its class is not
+plan updatable, or when the class would change. The command then exits with an error.`,
+		Example: `
+  svcatt diff -f cluster-instance-template.yaml
+  svcatt diff -f templates/ -o json
+`,
+		PreRunE: command.PreRunE(diffCmd),
+		RunE:    command.RunE(diffCmd),
+	}
+	cmd.Flags().StringSliceVarP(&diffCmd.filenames, "filename", "f", nil,
+		"File or directory containing instance templates. May be specified multiple times.")
+	cmd.Flags().StringVarP(
+		&diffCmd.ns,
+		"namespace",
+		"n",
+		"",
+		"The namespace of templates that do not specify one",
+	)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &diffCmd.output)
+
+	return cmd
+}
+
+func (c *diffCmd) Validate(args []string) error {
+	if len(c.filenames) == 0 {
+		return fmt.Errorf("at least one --filename is required")
+	}
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
+}
+
+func (c *diffCmd) Run() error {
+	objects, err := svcatt.LoadManifests(c.filenames, c.ns)
+	if err != nil {
+		return err
+	}
+
+	var proposed []templates.InstanceTemplateInterface
+	for _, obj := range objects {
+		if t, ok := obj.(templates.InstanceTemplateInterface); ok {
+			proposed = append(proposed, t)
+		}
+	}
+	if len(proposed) == 0 {
+		return fmt.Errorf("no instance templates are defined in the files")
+	}
+
+	impacts, err := c.App().DiffInstanceTemplates(proposed)
+	if err != nil {
+		return err
+	}
+	if err := svcattoutput.WriteTemplateImpacts(c.Output, c.format, impacts); err != nil {
+		return err
+	}
+
+	forbidden := 0
+	for _, impact := range impacts {
+		if impact.PlanChangeForbidden != "" {
+			forbidden++
+		}
+	}
+	if forbidden > 0 {
+		return fmt.Errorf("%d templated instances would change plan in a way that service catalog does not allow", forbidden)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type eventsCmd struct {
+	*svcattcommand.Context
+	ns       string
+	resource string
+	name     string
+	output   string
+	format   svcattoutput.Format
+}
+
+// NewEventsCmd builds a "svcat events" command
+func NewEventsCmd(cxt *svcattcommand.Context) *cobra.Command {
+	eventsCmd := &eventsCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "events TYPE NAME",
+		Short: "Show the events of a templated instance or binding and the resources it manages",
+		Long: `Show the events of a templated instance or binding and the resources it manages.
+
+The events of every resource shown by "svcat tree" are merged, from oldest to
+newest: the templated resources, their service instances and bindings,
+deployments, services and secrets. The controller also repeats the condition
+changes of service instances and bindings as events on the templated resource
+that manages them.
+
+TYPE is templated-instance (tinst) or templated-binding (tbnd).`,
+		Example: `
+  svcat events templated-instance wordpress-mysql-instance
+  svcat events tbnd wordpress-mysql-binding -n ci
+  svcat events tinst wordpress-mysql-instance -o yaml
+`,
+		PreRunE: command.PreRunE(eventsCmd),
+		RunE:    command.RunE(eventsCmd),
+	}
+	cmd.Flags().StringVarP(
+		&eventsCmd.ns,
+		"namespace",
+		"n",
+		"",
+		"The namespace of the resource",
+	)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &eventsCmd.output)
+	return cmd
+}
+
+func (c *eventsCmd) Validate(args []string) error {
+	if len(args) == 1 && strings.Contains(args[0], "/") {
+		args = strings.SplitN(args[0], "/", 2)
+	}
+	if len(args) != 2 || args[1] == "" {
+		return fmt.Errorf("a resource type and name are required, e.g. templated-instance NAME")
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "templated-instance", "templated-instances", "templatedinstance", "templatedinstances", "tinst":
+		c.resource = "templated-instance"
+	case "templated-binding", "templated-bindings", "templatedbinding", "templatedbindings", "tbnd":
+		c.resource = "templated-binding"
+	default:
+		return fmt.Errorf("invalid resource type %q, allowed types are: templated-instance, templated-binding", args[0])
+	}
+	c.name = args[1]
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
+}
+
+func (c *eventsCmd) Run() error {
+	var root *svcatt.TreeNode
+	var err error
+	if c.resource == "templated-instance" {
+		root, err = c.App().TemplatedInstanceTree(c.ns, c.name)
+	} else {
+		root, err = c.App().TemplatedBindingTree(c.ns, c.name)
+	}
+	if err != nil {
+		return err
+	}
+
+	events, err := c.App().TreeEvents(c.ns, root)
+	if err != nil {
+		return err
+	}
+	return svcattoutput.WriteEvents(c.Output, c.format, events)
+}
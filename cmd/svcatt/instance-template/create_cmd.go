@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package instancetemplate
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type createCmd struct {
+	*svcattcommand.Context
+	svcattcommand.ScopeFlags
+	specFlags
+
+	cmd        *cobra.Command
+	name       string
+	brokerName string
+}
+
+// NewCreateCmd builds a "svcat create instance-template" command
+func NewCreateCmd(cxt *svcattcommand.Context) *cobra.Command {
+	createCmd := &createCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "instance-template NAME --type SERVICE_TYPE",
+		Aliases: []string{"instancetemplate", "instt"},
+		Short:   "Create an instance template for a service type",
+		Long: `Create an instance template for a service type.
+
+The template is labeled with its service type, and its class and plan are
+validated against the catalog. A broker template applies to the broker of its
+class, unless --broker-name is specified.`,
+		Example: `
+  svcat create instance-template mysqldb --type mysqldb --class azure-mysqldb --plan basic50 -p location=eastus
+  svcat create instance-template mysqldb --cluster --type mysqldb --class azure-mysqldb --plan standard100
+  svcat create instance-template mysqldb --broker --type mysqldb --class azure-mysqldb -s mysecret[dbparams]
+`,
+		PreRunE: command.PreRunE(createCmd),
+		RunE:    command.RunE(createCmd),
+	}
+	createCmd.cmd = cmd
+	svcattcommand.AddScopeFlags(cmd.Flags(), &createCmd.ScopeFlags)
+	createCmd.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&createCmd.brokerName, "broker-name", "",
+		"The broker that a broker-level template applies to. Defaults to the broker of the class")
+	return cmd
+}
+
+func (c *createCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a template name is required")
+	}
+	c.name = args[0]
+
+	if c.serviceType == "" {
+		return fmt.Errorf("--type is required")
+	}
+	if c.brokerName != "" && !c.Broker {
+		return fmt.Errorf("--broker-name requires --broker")
+	}
+
+	if err := c.ScopeFlags.Validate(c.App().CurrentNamespace); err != nil {
+		return err
+	}
+	return c.validate()
+}
+
+func (c *createCmd) Run() error {
+	var spec templates.InstanceTemplateSpec
+	c.apply(c.cmd.Flags(), &spec)
+
+	instt := servicecatalogtempltesdk.BuildInstanceTemplate(c.Scope(), c.Namespace, c.name, c.brokerName, spec)
+	instt, err := c.App().CreateInstanceTemplate(instt)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteInstanceTemplateDetails(c.Output, instt)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package instancetemplate
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type deleteCmd struct {
+	*svcattcommand.Context
+	svcattcommand.ScopeFlags
+
+	name string
+}
+
+// NewDeleteCmd builds a "svcat delete instance-template" command
+func NewDeleteCmd(cxt *svcattcommand.Context) *cobra.Command {
+	deleteCmd := &deleteCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "instance-template NAME",
+		Aliases: []string{"instancetemplate", "instt"},
+		Short:   "Delete an instance template",
+		Example: `
+  svcat delete instance-template mysqldb
+  svcat delete instance-template mysqldb --cluster
+`,
+		PreRunE: command.PreRunE(deleteCmd),
+		RunE:    command.RunE(deleteCmd),
+	}
+	svcattcommand.AddScopeFlags(cmd.Flags(), &deleteCmd.ScopeFlags)
+	return cmd
+}
+
+func (c *deleteCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a template name is required")
+	}
+	c.name = args[0]
+
+	return c.ScopeFlags.Validate(c.App().CurrentNamespace)
+}
+
+func (c *deleteCmd) Run() error {
+	err := c.App().DeleteInstanceTemplate(c.Namespace, c.Cluster, c.Broker, c.name)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteDeletedInstanceTemplateName(c.Output, c.name)
+	return nil
+}
@@ -20,6 +20,8 @@ type describeCmd struct {
 	brokerLevel  bool
 	clusterLevel bool
 	serviceType  string
+	output       string
+	format       svcattoutput.Format
 }
 
 // NewDescribeCmd builds a "svcat describe instance-template" command
@@ -49,6 +51,8 @@ func NewDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
 	cmd.Flags().StringVarP(&describeCmd.serviceType, "type", "t", "",
 		"Filter the templates by a service type")
 
+	svcattcommand.AddOutputFlag(cmd.Flags(), &describeCmd.output)
+
 	return cmd
 }
 
@@ -61,7 +65,10 @@ func (c *describeCmd) Validate(args []string) error {
 	if c.ns == "" {
 		c.ns = c.App().CurrentNamespace
 	}
-	return nil
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *describeCmd) Run() error {
@@ -74,6 +81,10 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
+	if !c.format.IsTable() {
+		return svcattoutput.WriteObject(c.Output, c.format, instt)
+	}
+
 	svcattoutput.WriteInstanceTemplateDetails(c.Output, instt)
 	return nil
 }
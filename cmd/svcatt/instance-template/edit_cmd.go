@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package instancetemplate
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type editCmd struct {
+	*svcattcommand.Context
+	svcattcommand.ScopeFlags
+	specFlags
+
+	cmd  *cobra.Command
+	name string
+}
+
+// NewEditCmd builds a "svcat edit instance-template" command
+func NewEditCmd(cxt *svcattcommand.Context) *cobra.Command {
+	editCmd := &editCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "instance-template NAME",
+		Aliases: []string{"instancetemplate", "instt"},
+		Short:   "Change the fields of an instance template that are specified",
+		Long: `Change the fields of an instance template that are specified.
+
+Fields without a flag are left unchanged. --param, --params-json and --secret
+replace all the parameters of that kind. The class and plan are validated
+against the catalog.`,
+		Example: `
+  svcat edit instance-template mysqldb --plan standard100
+  svcat edit instance-template mysqldb --cluster -p location=westus
+`,
+		PreRunE: command.PreRunE(editCmd),
+		RunE:    command.RunE(editCmd),
+	}
+	editCmd.cmd = cmd
+	svcattcommand.AddScopeFlags(cmd.Flags(), &editCmd.ScopeFlags)
+	editCmd.addFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *editCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a template name is required")
+	}
+	c.name = args[0]
+
+	if c.cmd.Flags().Changed("type") && c.serviceType == "" {
+		return fmt.Errorf("--type cannot be empty")
+	}
+
+	if err := c.ScopeFlags.Validate(c.App().CurrentNamespace); err != nil {
+		return err
+	}
+	return c.validate()
+}
+
+func (c *editCmd) Run() error {
+	instt, err := c.App().GetInstanceTemplate(c.Namespace, c.Cluster, c.Broker, c.name)
+	if err != nil {
+		return err
+	}
+
+	c.apply(c.cmd.Flags(), instt.GetInstanceTemplateSpec())
+
+	instt, err = c.App().UpdateInstanceTemplate(instt)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteInstanceTemplateDetails(c.Output, instt)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type getCmd struct {
@@ -18,6 +19,8 @@ type getCmd struct {
 	brokerLevel   bool
 	clusterLevel  bool
 	serviceType   string
+	output        string
+	format        svcattoutput.Format
 }
 
 // NewGetCmd builds a "svcat get instance-templates" command
@@ -32,6 +35,7 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
   svcat get instance-templates --cluster
   svcat get instance-templates --broker
   svcat get instance-templates --type mysqldb
+  svcat get instance-templates --cluster -o json
 `,
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -54,6 +58,8 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
 	cmd.Flags().BoolVarP(&getCmd.clusterLevel, "cluster", "c", false, "List templates defined at the cluster-level")
 	cmd.Flags().StringVarP(&getCmd.serviceType, "type", "t", "", "Filter the templates by a service type")
 
+	svcattcommand.AddOutputFlag(cmd.Flags(), &getCmd.output)
+
 	return cmd
 }
 
@@ -66,7 +72,9 @@ func (c *getCmd) Validate(args []string) error {
 		c.ns = c.App().CurrentNamespace
 	}
 
-	return nil
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *getCmd) Run() error {
@@ -87,6 +95,14 @@ func (c *getCmd) getAll() error {
 		return err
 	}
 
+	if !c.format.IsTable() {
+		objs := make([]runtime.Object, 0, len(instts))
+		for _, instt := range instts {
+			objs = append(objs, instt)
+		}
+		return svcattoutput.WriteObjectList(c.Output, c.format, objs...)
+	}
+
 	svcattoutput.WriteInstanceTemplateList(c.Output, instts...)
 	return nil
 }
@@ -97,6 +113,10 @@ func (c *getCmd) get() error {
 		return err
 	}
 
+	if !c.format.IsTable() {
+		return svcattoutput.WriteObject(c.Output, c.format, instt)
+	}
+
 	svcattoutput.WriteInstanceTemplateList(c.Output, instt)
 	return nil
 }
@@ -0,0 +1,85 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package instancetemplate
+
+import (
+	"fmt"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/pflag"
+)
+
+// specFlags are the flags that set the spec of an instance template.
+type specFlags struct {
+	serviceType string
+	className   string
+	planName    string
+	rawParams   []string
+	jsonParams  string
+	params      interface{}
+	rawSecrets  []string
+	secrets     map[string]string
+}
+
+func (f *specFlags) addFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&f.serviceType, "type", "t", "",
+		"The service type that the template applies to")
+	flags.StringVar(&f.className, "class", "",
+		"The class name")
+	flags.StringVar(&f.planName, "plan", "",
+		"The plan name, requires --class")
+	flags.StringSliceVarP(&f.rawParams, "param", "p", nil,
+		"Parameter to use when provisioning the service, format: NAME=VALUE. Cannot be combined with --params-json")
+	flags.StringSliceVarP(&f.rawSecrets, "secret", "s", nil,
+		"Parameter, whose value is stored in a secret, to use when provisioning the service, format: SECRET[KEY]")
+	flags.StringVar(&f.jsonParams, "params-json", "",
+		"Parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param")
+}
+
+func (f *specFlags) validate() error {
+	if f.jsonParams != "" && len(f.rawParams) > 0 {
+		return fmt.Errorf("--params-json cannot be used with --param")
+	}
+
+	var err error
+	if f.jsonParams != "" {
+		f.params, err = parameters.ParseVariableJSON(f.jsonParams)
+		if err != nil {
+			return fmt.Errorf("invalid --params-json value (%s)", err)
+		}
+	} else {
+		f.params, err = parameters.ParseVariableAssignments(f.rawParams)
+		if err != nil {
+			return fmt.Errorf("invalid --param value (%s)", err)
+		}
+	}
+
+	f.secrets, err = parameters.ParseKeyMaps(f.rawSecrets)
+	if err != nil {
+		return fmt.Errorf("invalid --secret value (%s)", err)
+	}
+
+	return nil
+}
+
+// apply sets the fields of the spec whose flags were specified.
+func (f *specFlags) apply(flags *pflag.FlagSet, spec *templates.InstanceTemplateSpec) {
+	if flags.Changed("type") {
+		spec.ServiceType = f.serviceType
+	}
+	if flags.Changed("class") {
+		spec.ClusterServiceClassExternalName = f.className
+	}
+	if flags.Changed("plan") {
+		spec.ClusterServicePlanExternalName = f.planName
+	}
+	if flags.Changed("param") || flags.Changed("params-json") {
+		spec.Parameters = svcat.BuildParameters(f.params)
+	}
+	if flags.Changed("secret") {
+		spec.ParametersFrom = svcat.BuildParametersFrom(f.secrets)
+	}
+}
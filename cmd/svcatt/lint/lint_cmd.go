@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package lint
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type lintCmd struct {
+	*svcattcommand.Context
+	ns        string
+	filenames []string
+	catalog   bool
+	strict    bool
+	output    string
+	format    svcattoutput.Format
+}
+
+// NewLintCmd builds a "svcatt lint" command
+func NewLintCmd(cxt *svcattcommand.Context) *cobra.Command {
+	lintCmd := &lintCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check templates for problems that would only surface when a templated resource is resolved",
+		Long: `Check templates for problems that would only surface when a templated resource is resolved.
+
+Without --filename the templates in the cluster are checked, otherwise the templates
+defined in the files are checked without connecting to a cluster. Errors are reported for:
+
+  * templates without the serviceType label, which are never used
+  * more than one template for a service type in the same namespace, or cluster wide
+  * broker templates for a broker that is not installed
+  * classes and plans that do not exist in the catalog
+
+Warnings are reported for a serviceType label that does not match spec.serviceType,
+more than one broker template for a service type, and classes offered by another
+broker than the one of their broker template.
+
+The catalog is only checked when linting the cluster, or files with --catalog.
+The command exits with an error when errors are found, or warnings with --strict.`,
+		Example: `
+  svcatt lint
+  svcatt lint -f templates/
+  svcatt lint -f templates/ --catalog --strict
+`,
+		Annotations: map[string]string{svcattcommand.OfflineAnnotation: "true"},
+		PreRunE:     command.PreRunE(lintCmd),
+		RunE:        command.RunE(lintCmd),
+	}
+	cmd.Flags().StringSliceVarP(&lintCmd.filenames, "filename", "f", nil,
+		"File or directory containing templates. May be specified multiple times.")
+	cmd.Flags().StringVarP(
+		&lintCmd.ns,
+		"namespace",
+		"n",
+		"default",
+		"The namespace of templates that do not specify one",
+	)
+	cmd.Flags().BoolVar(&lintCmd.catalog, "catalog", false,
+		"Check the templates from the files against the brokers, classes and plans in the cluster")
+	cmd.Flags().BoolVar(&lintCmd.strict, "strict", false,
+		"Exit with an error when warnings are found")
+	svcattcommand.AddOutputFlag(cmd.Flags(), &lintCmd.output)
+
+	return cmd
+}
+
+func (c *lintCmd) Validate(args []string) error {
+	if c.catalog && len(c.filenames) == 0 {
+		return fmt.Errorf("--catalog requires --filename, the templates in the cluster are always checked against the catalog")
+	}
+	if (c.catalog || len(c.filenames) == 0) && c.App() == nil {
+		return fmt.Errorf("unable to connect to the cluster")
+	}
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
+}
+
+func (c *lintCmd) Run() error {
+	var catalog servicecatalogtempltesdk.Catalog
+	if len(c.filenames) == 0 || c.catalog {
+		catalog = c.App().ServiceCatalogApp
+	}
+
+	var sdk *servicecatalogtempltesdk.SDK
+	if len(c.filenames) > 0 {
+		objects, err := svcatt.LoadManifests(c.filenames, c.ns)
+		if err != nil {
+			return err
+		}
+		sdk, err = servicecatalogtempltesdk.NewOffline(objects...)
+		if err != nil {
+			return err
+		}
+	} else {
+		sdk = c.App().SDK
+	}
+
+	problems, err := sdk.LintTemplates(catalog)
+	if err != nil {
+		return err
+	}
+	if err := svcattoutput.WriteLintProblems(c.Output, c.format, problems); err != nil {
+		return err
+	}
+
+	errs, warnings := 0, 0
+	for _, p := range problems {
+		if p.Severity == servicecatalogtempltesdk.LintError {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	if errs > 0 {
+		return fmt.Errorf("%d errors found in the templates", errs)
+	}
+	if c.strict && warnings > 0 {
+		return fmt.Errorf("%d warnings found in the templates", warnings)
+	}
+	return nil
+}
@@ -7,11 +7,20 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/apply"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/binding-template"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/diff"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/events"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/instance-template"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/lint"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/render"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/service-type"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/status"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/templated-binding"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/templated-instance"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/tree"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/wait"
 	"github.com/Azure/service-catalog-templates/pkg"
 	"github.com/Azure/service-catalog-templates/pkg/svcatt"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/binding"
@@ -55,9 +64,15 @@ func buildRootCommand() *cobra.Command {
 			bindViperToCobra(cxt.Viper, cmd)
 
 			app, err := svcatt.NewApp(opts.KubeConfig, opts.KubeContext)
+			if err != nil {
+				if _, offline := cmd.Annotations[svcattcommand.OfflineAnnotation]; offline {
+					return nil
+				}
+				return err
+			}
 			cxt.SetApp(app)
 
-			return err
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.Version {
@@ -81,13 +96,25 @@ func buildRootCommand() *cobra.Command {
 
 	cmd.AddCommand(newGetCmd(cxt))
 	cmd.AddCommand(newDescribeCmd(cxt))
+	cmd.AddCommand(newCreateCmd(cxt))
+	cmd.AddCommand(newEditCmd(cxt))
+	cmd.AddCommand(newDeleteCmd(cxt))
+	cmd.AddCommand(newUpdateCmd(cxt))
 	cmd.AddCommand(templatedinstance.NewProvisionCmd(cxt))
 	cmd.AddCommand(templatedinstance.NewDeprovisionCmd(cxt))
 	cmd.AddCommand(templatedbinding.NewBindCmd(cxt))
 	cmd.AddCommand(templatedbinding.NewUnbindCmd(cxt))
+	cmd.AddCommand(wait.NewWaitCmd(cxt))
+	cmd.AddCommand(status.NewStatusCmd(cxt))
+	cmd.AddCommand(tree.NewTreeCmd(cxt))
+	cmd.AddCommand(events.NewEventsCmd(cxt))
 	cmd.AddCommand(newSyncCmd(cxt))
 	cmd.AddCommand(newInstallCmd(cxt))
 	cmd.AddCommand(newTouchCmd(cxt))
+	cmd.AddCommand(render.NewRenderCmd(cxt))
+	cmd.AddCommand(apply.NewApplyCmd(cxt))
+	cmd.AddCommand(diff.NewDiffCmd(cxt))
+	cmd.AddCommand(lint.NewLintCmd(cxt))
 
 	return cmd
 }
@@ -141,6 +168,50 @@ func newDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
 	cmd.AddCommand(templatedbinding.NewDescribeCmd(cxt))
 	cmd.AddCommand(instancetemplate.NewDescribeCmd(cxt))
 	cmd.AddCommand(bindingtemplate.NewDescribeCmd(cxt))
+	cmd.AddCommand(servicetype.NewDescribeCmd(cxt))
+
+	return cmd
+}
+
+func newCreateCmd(cxt *svcattcommand.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a template",
+	}
+	cmd.AddCommand(instancetemplate.NewCreateCmd(cxt))
+	cmd.AddCommand(bindingtemplate.NewCreateCmd(cxt))
+
+	return cmd
+}
+
+func newEditCmd(cxt *svcattcommand.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Change the fields of a template",
+	}
+	cmd.AddCommand(instancetemplate.NewEditCmd(cxt))
+	cmd.AddCommand(bindingtemplate.NewEditCmd(cxt))
+
+	return cmd
+}
+
+func newDeleteCmd(cxt *svcattcommand.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a template",
+	}
+	cmd.AddCommand(instancetemplate.NewDeleteCmd(cxt))
+	cmd.AddCommand(bindingtemplate.NewDeleteCmd(cxt))
+
+	return cmd
+}
+
+func newUpdateCmd(cxt *svcattcommand.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Change a templated resource",
+	}
+	cmd.AddCommand(templatedinstance.NewUpdateCmd(cxt))
 
 	return cmd
 }
@@ -160,6 +231,7 @@ func newTouchCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Short: "Update a resource to trigger reprocessing",
 	}
 	cmd.AddCommand(instance.NewTouchCommand(cxt.Context))
+	cmd.AddCommand(templatedinstance.NewTouchCmd(cxt))
 	return cmd
 }
 
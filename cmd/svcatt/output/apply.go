@@ -0,0 +1,54 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+)
+
+// WriteApplyPlan prints the changes that applying the manifests would make.
+func WriteApplyPlan(w io.Writer, changes []svcatt.ApplyChange) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No resources to apply")
+		return
+	}
+
+	t := output.NewListTable(w)
+	t.SetHeader([]string{
+		"Action",
+		"Kind",
+		"Namespace",
+		"Name",
+	})
+	for _, change := range changes {
+		t.Append([]string{
+			string(change.Action),
+			change.Kind,
+			change.Namespace,
+			change.Name,
+		})
+	}
+	t.Render()
+}
+
+// WriteAppliedChange prints a change once it is made.
+func WriteAppliedChange(w io.Writer, change svcatt.ApplyChange) {
+	var result string
+	switch change.Action {
+	case svcatt.ApplyCreate:
+		result = "created"
+	case svcatt.ApplyUpdate:
+		result = "updated"
+	case svcatt.ApplyPrune:
+		result = "pruned"
+	default:
+		result = "unchanged"
+	}
+	fmt.Fprintf(w, "%s/%s %s\n", strings.ToLower(change.Kind), change.Name, result)
+}
@@ -4,6 +4,7 @@
 package svcattoutput
 
 import (
+	"fmt"
 	"io"
 
 	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
@@ -43,3 +44,8 @@ func WriteBindingTemplateDetails(w io.Writer, bndt templates.BindingTemplateInte
 
 	t.Render()
 }
+
+// WriteDeletedBindingTemplateName prints the name of a deleted binding template.
+func WriteDeletedBindingTemplateName(w io.Writer, name string) {
+	fmt.Fprintf(w, "deleted %s\n", name)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+)
+
+// WriteDeprovisionPlan prints what deprovisioning an instance would delete, and the
+// workloads that still reference the secrets of its bindings.
+func WriteDeprovisionPlan(w io.Writer, plan *svcatt.DeprovisionPlan, cascade bool) {
+	fmt.Fprintf(w, "Instance %s/%s would be deleted\n", plan.Instance.Namespace, plan.Instance.Name)
+
+	fmt.Fprintln(w, "\nBindings:")
+	if len(plan.Bindings) == 0 {
+		fmt.Fprintln(w, "No bindings defined")
+	} else {
+		action := "left in place, use --cascade to remove them"
+		if cascade {
+			action = "removed first"
+		}
+		t := output.NewListTable(w)
+		t.SetHeader([]string{
+			"Name",
+			"Secret",
+		})
+		for _, tbnd := range plan.Bindings {
+			t.Append([]string{
+				tbnd.Name,
+				tbnd.Spec.SecretName,
+			})
+		}
+		t.Render()
+		fmt.Fprintf(w, "Bindings would be %s\n", action)
+	}
+
+	fmt.Fprintln(w, "\nConsumers:")
+	WriteSecretConsumers(w, plan.Consumers)
+	WriteUnscannedWorkloads(w, plan.Unscanned)
+}
+
+// WriteUnscannedWorkloads prints the kinds of workload that could not be checked for
+// references to the binding secrets.
+func WriteUnscannedWorkloads(w io.Writer, unscanned []string) {
+	if len(unscanned) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Not allowed to list %s, they were not checked for references to the binding secrets\n",
+		strings.Join(unscanned, ", "))
+}
+
+// WriteSecretConsumers prints the workloads that reference projected secrets.
+func WriteSecretConsumers(w io.Writer, consumers []svcatt.SecretConsumer) {
+	if len(consumers) == 0 {
+		fmt.Fprintln(w, "No workloads reference the binding secrets")
+		return
+	}
+
+	t := output.NewListTable(w)
+	t.SetHeader([]string{
+		"Workload",
+		"Secret",
+		"Binding",
+	})
+	for _, c := range consumers {
+		t.Append([]string{
+			fmt.Sprintf("%s/%s", c.Kind, c.Name),
+			c.Secret,
+			c.Binding,
+		})
+	}
+	t.Render()
+}
+
+// WriteDeletedTemplatedInstanceName prints the name of a deprovisioned instance.
+func WriteDeletedTemplatedInstanceName(w io.Writer, instanceName string) {
+	fmt.Fprintf(w, "deleted %s\n", instanceName)
+}
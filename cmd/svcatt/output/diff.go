@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+)
+
+// WriteTemplateImpacts prints how the resolution of each templated instance would change,
+// followed by how many of the instances would change.
+func WriteTemplateImpacts(w io.Writer, f Format, impacts []servicecatalogtempltesdk.TemplateImpact) error {
+	if !f.IsTable() {
+		names := make([]string, 0, len(impacts))
+		for _, impact := range impacts {
+			if impact.Changed() {
+				names = append(names, "templatedinstance/"+impact.Name)
+			}
+		}
+		return writeFormatted(w, f, impacts, names)
+	}
+
+	if len(impacts) == 0 {
+		fmt.Fprintln(w, "No templated instances use the templates")
+		return nil
+	}
+
+	changed := 0
+	for _, impact := range impacts {
+		if !impact.Changed() {
+			continue
+		}
+		changed++
+
+		fmt.Fprintf(w, "%s/%s\n", impact.Namespace, impact.Name)
+		if impact.Error != "" {
+			fmt.Fprintf(w, "  Error: %s\n", impact.Error)
+			continue
+		}
+		if impact.PlanChanged() {
+			fmt.Fprintf(w, "  Plan: %s -> %s\n", planName(impact.CurrentClass, impact.CurrentPlan),
+				planName(impact.ProposedClass, impact.ProposedPlan))
+		}
+		if impact.PlanChangeForbidden != "" {
+			fmt.Fprintf(w, "  Forbidden: %s\n", impact.PlanChangeForbidden)
+		}
+		for _, p := range impact.Parameters {
+			switch {
+			case p.Current == "":
+				fmt.Fprintf(w, "  + %s: %s\n", p.Name, p.Proposed)
+			case p.Proposed == "":
+				fmt.Fprintf(w, "  - %s: %s\n", p.Name, p.Current)
+			default:
+				fmt.Fprintf(w, "  ~ %s: %s -> %s\n", p.Name, p.Current, p.Proposed)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "%d of %d templated instances would change\n", changed, len(impacts))
+	return nil
+}
+
+func planName(class, plan string) string {
+	if class == "" && plan == "" {
+		return "<none>"
+	}
+	return class + "/" + plan
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WriteEvents prints events, in the order given, with how long ago each was last seen.
+func WriteEvents(w io.Writer, f Format, events []core.Event) error {
+	if !f.IsTable() {
+		names := make([]string, 0, len(events))
+		for _, e := range events {
+			names = append(names, "event/"+e.Name)
+		}
+		return writeFormatted(w, f, events, names)
+	}
+
+	t := output.NewListTable(w)
+	t.SetHeader([]string{
+		"Last Seen",
+		"Type",
+		"Reason",
+		"Object",
+		"Message",
+	})
+	for _, e := range events {
+		t.Append([]string{
+			eventAge(e.LastTimestamp),
+			e.Type,
+			e.Reason,
+			fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, e.InvolvedObject.Name),
+			e.Message,
+		})
+	}
+	t.Render()
+	return nil
+}
+
+// eventAge formats how long ago an event was seen with its largest unit, e.g. 5m.
+func eventAge(t meta.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	d := time.Since(t.Time)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+
+	templatesscheme "github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned/scheme"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// Output formats selected with the --output flag. The default is a table.
+const (
+	FormatJSON       = "json"
+	FormatYAML       = "yaml"
+	FormatName       = "name"
+	FormatJSONPath   = "jsonpath"
+	FormatGoTemplate = "go-template"
+)
+
+// Format is a parsed --output flag, e.g. json or jsonpath={.status}.
+type Format struct {
+	Type     string
+	Template string
+}
+
+// ParseFormat parses the value of the --output flag.
+func ParseFormat(value string) (Format, error) {
+	parts := strings.SplitN(value, "=", 2)
+	f := Format{Type: parts[0]}
+	if len(parts) == 2 {
+		f.Template = parts[1]
+	}
+
+	switch f.Type {
+	case "", FormatJSON, FormatYAML, FormatName:
+		if f.Template != "" {
+			return Format{}, fmt.Errorf("the %s output format does not accept a template", f.Type)
+		}
+	case FormatJSONPath, FormatGoTemplate:
+		if f.Template == "" {
+			return Format{}, fmt.Errorf("the %s output format requires a template, e.g. %s=TEMPLATE", f.Type, f.Type)
+		}
+	default:
+		return Format{}, fmt.Errorf("invalid output format %q, allowed formats are: json, yaml, name, jsonpath=TEMPLATE, go-template=TEMPLATE", value)
+	}
+	return f, nil
+}
+
+// IsTable determines if the default table output was selected.
+func (f Format) IsTable() bool {
+	return f.Type == ""
+}
+
+// objectList is printed for commands that return more than one resource.
+type objectList struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Items      []interface{} `json:"items"`
+}
+
+// WriteObject prints a templates resource in the format.
+func WriteObject(w io.Writer, f Format, obj runtime.Object) error {
+	return writeFormatted(w, f, setKind(obj), []string{objectName(obj)})
+}
+
+// WriteObjectList prints templates resources as a List in the format.
+func WriteObjectList(w io.Writer, f Format, objs ...runtime.Object) error {
+	list := objectList{APIVersion: "v1", Kind: "List", Items: make([]interface{}, 0, len(objs))}
+	names := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		list.Items = append(list.Items, setKind(obj))
+		names = append(names, objectName(obj))
+	}
+	return writeFormatted(w, f, list, names)
+}
+
+func writeFormatted(w io.Writer, f Format, data interface{}, names []string) error {
+	switch f.Type {
+	case FormatJSON:
+		j, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(j))
+	case FormatYAML:
+		y, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(y))
+	case FormatName:
+		for _, name := range names {
+			fmt.Fprintln(w, name)
+		}
+	case FormatJSONPath:
+		values, err := toUnstructured(data)
+		if err != nil {
+			return err
+		}
+		jp := jsonpath.New("output")
+		if err := jp.Parse(f.Template); err != nil {
+			return fmt.Errorf("invalid jsonpath template (%s)", err)
+		}
+		return jp.Execute(w, values)
+	case FormatGoTemplate:
+		values, err := toUnstructured(data)
+		if err != nil {
+			return err
+		}
+		t, err := template.New("output").Parse(f.Template)
+		if err != nil {
+			return fmt.Errorf("invalid go-template (%s)", err)
+		}
+		return t.Execute(w, values)
+	default:
+		return fmt.Errorf("unsupported output format %q", f.Type)
+	}
+	return nil
+}
+
+// toUnstructured converts data to generic values so that templates use the json field names.
+func toUnstructured(data interface{}) (interface{}, error) {
+	j, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var values interface{}
+	err = json.Unmarshal(j, &values)
+	return values, err
+}
+
+// setKind populates the apiVersion and kind, which the API client does not set on retrieved resources.
+func setKind(obj runtime.Object) runtime.Object {
+	kinds, _, err := templatesscheme.Scheme.ObjectKinds(obj)
+	if err == nil && len(kinds) > 0 {
+		obj.GetObjectKind().SetGroupVersionKind(kinds[0])
+	}
+	return obj
+}
+
+// objectName identifies a resource as kind/name, like kubectl.
+func objectName(obj runtime.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		if kinds, _, err := templatesscheme.Scheme.ObjectKinds(obj); err == nil && len(kinds) > 0 {
+			kind = kinds[0].Kind
+		}
+	}
+
+	name := ""
+	if accessor, err := meta.Accessor(obj); err == nil {
+		name = accessor.GetName()
+	}
+	return strings.ToLower(kind) + "/" + name
+}
+
+// Hierarchy is the class, plan and broker that a templated resource was traversed to.
+type Hierarchy struct {
+	Class  *svcat.ClusterServiceClass  `json:"class,omitempty"`
+	Plan   *svcat.ClusterServicePlan   `json:"plan,omitempty"`
+	Broker *svcat.ClusterServiceBroker `json:"broker,omitempty"`
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+func TestWriteObjectList(t *testing.T) {
+	tinsts := []*templates.TemplatedInstance{
+		{ObjectMeta: metav1.ObjectMeta{Name: "db1"}, Spec: templates.TemplatedInstanceSpec{ServiceType: "mysqldb"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db2"}, Spec: templates.TemplatedInstanceSpec{ServiceType: "redis"}},
+	}
+
+	testcases := []struct {
+		output string
+		want   string
+	}{
+		{"name", "templatedinstance/db1\ntemplatedinstance/db2\n"},
+		{"jsonpath={.items[*].spec.serviceType}", "mysqldb redis"},
+		{"go-template={{range .items}}{{.kind}} {{end}}", "TemplatedInstance TemplatedInstance "},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.output, func(t *testing.T) {
+			f, err := ParseFormat(tc.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			if err := WriteObjectList(&buf, f, tinsts[0], tinsts[1]); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Fatalf("expected %q got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseFormat_Invalid(t *testing.T) {
+	for _, output := range []string{"xml", "jsonpath", "json=foo"} {
+		if _, err := ParseFormat(output); err == nil {
+			t.Errorf("expected %q to be rejected", output)
+		}
+	}
+}
@@ -61,3 +61,8 @@ func WriteInstanceTemplateDetails(w io.Writer, instt templates.InstanceTemplateI
 
 	t.Render()
 }
+
+// WriteDeletedInstanceTemplateName prints the name of a deleted instance template.
+func WriteDeletedInstanceTemplateName(w io.Writer, name string) {
+	fmt.Fprintf(w, "deleted %s\n", name)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+)
+
+// WriteLintProblems prints the problems found with templates, followed by how many
+// errors and warnings were found.
+func WriteLintProblems(w io.Writer, f Format, problems []servicecatalogtempltesdk.LintProblem) error {
+	if !f.IsTable() {
+		names := []string{}
+		seen := map[string]bool{}
+		for _, p := range problems {
+			if !seen[p.Template] {
+				seen[p.Template] = true
+				names = append(names, p.Template)
+			}
+		}
+		return writeFormatted(w, f, problems, names)
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintln(w, "No problems found")
+		return nil
+	}
+
+	errs, warnings := 0, 0
+	t := output.NewListTable(w)
+	t.SetHeader([]string{
+		"Severity",
+		"Template",
+		"Message",
+	})
+	for _, p := range problems {
+		if p.Severity == servicecatalogtempltesdk.LintError {
+			errs++
+		} else {
+			warnings++
+		}
+		t.Append([]string{
+			string(p.Severity),
+			p.Template,
+			p.Message,
+		})
+	}
+	t.Render()
+
+	fmt.Fprintf(w, "\n%d errors, %d warnings\n", errs, warnings)
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+)
+
+// secretMapping describes how the secret populated by Service Catalog for a
+// binding is projected into the secret used by the application.
+type secretMapping struct {
+	Kind                 string            `json:"kind"`
+	Namespace            string            `json:"namespace"`
+	TemplatedBinding     string            `json:"templatedBinding"`
+	ServiceCatalogSecret string            `json:"serviceCatalogSecret"`
+	Secret               string            `json:"secret"`
+	SecretKeys           map[string]string `json:"secretKeys,omitempty"`
+}
+
+// WriteRendering prints the service instances, service bindings and secret
+// mappings produced by resolving templated resources, as a multi-document YAML stream.
+// Instances provided by a container are rendered as their deployment, service and
+// credential secrets.
+func WriteRendering(w io.Writer, instances []*servicecatalogtempltesdk.InstanceResolution, bindings []*servicecatalogtempltesdk.BindingResolution) {
+	first := true
+	writeDoc := func(obj interface{}) {
+		if !first {
+			fmt.Fprintln(w, "---")
+		}
+		first = false
+		writeYAML(w, obj, 0)
+	}
+
+	for _, res := range instances {
+		if res.ServiceInstance != nil {
+			writeDoc(res.ServiceInstance)
+		} else {
+			writeDoc(res.Deployment)
+			writeDoc(res.Service)
+		}
+	}
+
+	for _, res := range bindings {
+		if res.ServiceBinding != nil {
+			writeDoc(res.ServiceBinding)
+		} else {
+			writeDoc(res.Secret)
+		}
+
+		tbnd := res.TemplatedBinding
+		writeDoc(secretMapping{
+			Kind:                 "SecretMapping",
+			Namespace:            tbnd.Namespace,
+			TemplatedBinding:     tbnd.Name,
+			ServiceCatalogSecret: builder.ShadowSecretName(tbnd.Spec.SecretName),
+			Secret:               tbnd.Spec.SecretName,
+			SecretKeys:           tbnd.Spec.SecretKeys,
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+)
+
+// WriteInstanceResolution prints the templates that contributed to a resolved
+// templated instance, and the service instance, or the deployment and service
+// of a container provided instance, that would be created.
+func WriteInstanceResolution(w io.Writer, res *servicecatalogtempltesdk.InstanceResolution) {
+	fmt.Fprintln(w, "Templates:")
+	if len(res.Templates) == 0 {
+		fmt.Fprintln(w, "No templates apply")
+	} else {
+		WriteInstanceTemplateList(w, res.Templates...)
+	}
+
+	if res.ServiceInstance == nil {
+		fmt.Fprintln(w, "\nDeployment:")
+		writeYAML(w, res.Deployment, 2)
+		fmt.Fprintln(w, "\nService:")
+		writeYAML(w, res.Service, 2)
+		return
+	}
+
+	fmt.Fprintln(w, "\nServiceInstance:")
+	writeYAML(w, res.ServiceInstance, 2)
+}
+
+// WriteBindingResolution prints the templates that contributed to a resolved
+// templated binding, and the service binding, or the credential secret of a
+// container provided instance, that would be created.
+func WriteBindingResolution(w io.Writer, res *servicecatalogtempltesdk.BindingResolution) {
+	fmt.Fprintln(w, "Templates:")
+	if len(res.Templates) == 0 {
+		fmt.Fprintln(w, "No templates apply")
+	} else {
+		WriteBindingTemplateList(w, res.Templates...)
+	}
+
+	if res.ServiceBinding != nil {
+		fmt.Fprintln(w, "\nServiceBinding:")
+		writeYAML(w, res.ServiceBinding, 2)
+	} else {
+		fmt.Fprintln(w, "\nSecret:")
+		writeYAML(w, res.Secret, 2)
+	}
+
+	if len(res.TemplatedBinding.Spec.SecretKeys) > 0 {
+		fmt.Fprintln(w, "\nSecret Keys:")
+		writeYAML(w, res.TemplatedBinding.Spec.SecretKeys, 2)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+)
+
+// WriteServiceTypeDescription prints the effective templates of a service type in
+// a namespace, with the template that each value came from.
+func WriteServiceTypeDescription(w io.Writer, f Format, d *servicecatalogtempltesdk.ServiceTypeDescription) error {
+	if !f.IsTable() {
+		return writeFormatted(w, f, d, []string{"servicetype/" + d.ServiceType})
+	}
+
+	t := output.NewDetailsTable(w)
+	t.AppendBulk([][]string{
+		{"Service Type:", d.ServiceType},
+		{"Namespace:", d.Namespace},
+	})
+	t.Render()
+
+	fmt.Fprintln(w, "\nInstance Templates:")
+	writeTemplateSources(w, d.Instance.Templates)
+	fmt.Fprintln(w)
+	t = output.NewDetailsTable(w)
+	for _, setting := range []struct {
+		label string
+		value *servicecatalogtempltesdk.SourcedValue
+	}{
+		{"Provider:", d.Instance.Provider},
+		{"Class:", d.Instance.Class},
+		{"Plan:", d.Instance.Plan},
+	} {
+		if setting.value != nil {
+			t.Append([]string{setting.label, fmt.Sprintf("%s (%s)", setting.value.Value, setting.value.Source)})
+		}
+	}
+	t.Render()
+	writeSourcedValues(w, "Parameters", "Name", "Value", d.Instance.Parameters)
+	writeSourcedValues(w, "Parameters From", "Secret", "Key", d.Instance.ParametersFrom)
+
+	fmt.Fprintln(w, "\nBinding Templates:")
+	writeTemplateSources(w, d.Binding.Templates)
+	writeSourcedValues(w, "Parameters", "Name", "Value", d.Binding.Parameters)
+	writeSourcedValues(w, "Parameters From", "Secret", "Key", d.Binding.ParametersFrom)
+	writeSourcedValues(w, "Secret Keys", "Key", "Mapped To", d.Binding.SecretKeys)
+
+	if len(d.Warnings) > 0 {
+		fmt.Fprintln(w, "\nWarnings:")
+		for _, warning := range d.Warnings {
+			fmt.Fprintf(w, "  %s\n", warning)
+		}
+	}
+	return nil
+}
+
+func writeTemplateSources(w io.Writer, sources []servicecatalogtempltesdk.TemplateSource) {
+	if len(sources) == 0 {
+		fmt.Fprintln(w, "  No templates apply")
+		return
+	}
+
+	t := output.NewListTable(w)
+	t.SetHeader([]string{"Name", "Scope"})
+	for _, source := range sources {
+		t.Append([]string{source.Name, string(source.Scope)})
+	}
+	t.Render()
+}
+
+func writeSourcedValues(w io.Writer, title, nameHeader, valueHeader string, values []servicecatalogtempltesdk.SourcedValue) {
+	if len(values) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s:\n", title)
+	t := output.NewListTable(w)
+	t.SetHeader([]string{nameHeader, valueHeader, "Source"})
+	for _, v := range values {
+		t.Append([]string{v.Name, v.Value, v.Source.String()})
+	}
+	t.Render()
+}
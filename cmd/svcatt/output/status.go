@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+)
+
+// WriteTemplatedInstanceSummaries prints the number of instances of each service
+// type, provider and broker by status.
+func WriteTemplatedInstanceSummaries(w io.Writer, f Format, summaries []servicecatalogtempltesdk.TemplatedInstanceSummary) error {
+	if !f.IsTable() {
+		names := make([]string, 0, len(summaries))
+		for _, s := range summaries {
+			names = append(names, "servicetype/"+s.ServiceType)
+		}
+		return writeFormatted(w, f, summaries, names)
+	}
+
+	t := output.NewListTable(w)
+	t.SetHeader([]string{
+		"Service Type",
+		"Provider",
+		"Broker",
+		"Ready",
+		"Pending",
+		"Failed",
+		"Total",
+	})
+	for _, s := range summaries {
+		t.Append([]string{
+			s.ServiceType,
+			string(s.Provider),
+			s.Broker,
+			strconv.Itoa(s.Ready),
+			strconv.Itoa(s.Pending),
+			strconv.Itoa(s.Failed),
+			strconv.Itoa(s.Total()),
+		})
+	}
+	t.Render()
+	return nil
+}
@@ -8,25 +8,38 @@ import (
 	"io"
 
 	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 )
 
 // WriteTemplatedBindingList prints a list of bindings.
 func WriteTemplatedBindingList(w io.Writer, bindings ...templates.TemplatedBinding) {
+	writeTemplatedBindingTable(w, true, bindings...)
+}
+
+// WriteTemplatedBindingRow prints a binding as a row of the list, without its
+// header, as it changes while being watched.
+func WriteTemplatedBindingRow(w io.Writer, binding *templates.TemplatedBinding) {
+	writeTemplatedBindingTable(w, false, *binding)
+}
+
+func writeTemplatedBindingTable(w io.Writer, header bool, bindings ...templates.TemplatedBinding) {
 	t := output.NewListTable(w)
-	t.SetHeader([]string{
-		"Name",
-		"Namespace",
-		"Instance",
-		"Status",
-	})
+	if header {
+		t.SetHeader([]string{
+			"Name",
+			"Namespace",
+			"Instance",
+			"Status",
+		})
+	}
 
 	for _, binding := range bindings {
 		t.Append([]string{
 			binding.Name,
 			binding.Namespace,
 			binding.Spec.TemplatedInstanceRef.Name,
-			"",
+			builder.GetStatus(binding.Status.Conditions),
 		})
 	}
 
@@ -40,7 +53,7 @@ func WriteTemplatedBindingDetails(w io.Writer, binding *templates.TemplatedBindi
 	t.AppendBulk([][]string{
 		{"Name:", binding.Name},
 		{"Namespace:", binding.Namespace},
-		{"Status:", ""},
+		{"Status:", builder.GetStatus(binding.Status.Conditions)},
 		{"Instance:", binding.Spec.TemplatedInstanceRef.Name},
 	})
 
@@ -63,7 +76,7 @@ func WriteAssociatedTemplatedBindings(w io.Writer, bindings []templates.Template
 	for _, binding := range bindings {
 		t.Append([]string{
 			binding.Name,
-			"",
+			builder.GetStatus(binding.Status.Conditions),
 		})
 	}
 	t.Render()
@@ -80,3 +93,23 @@ func WriteDeletedTemplatedBindingNames(w io.Writer, bindings []templates.Templat
 func WriteDeletedTemplatedBindingName(w io.Writer, bindingName string) {
 	fmt.Fprintf(w, "deleted %s\n", bindingName)
 }
+
+// templatedBindingDescription is the structured output of describe templated-binding.
+type templatedBindingDescription struct {
+	*templates.TemplatedBinding
+	Instance  *templates.TemplatedInstance `json:"instance,omitempty"`
+	Hierarchy *Hierarchy                   `json:"hierarchy,omitempty"`
+}
+
+// WriteTemplatedBindingDescription prints a templated binding, with its instance
+// and parent hierarchy when it was traversed, in the format.
+func WriteTemplatedBindingDescription(w io.Writer, f Format, tbnd *templates.TemplatedBinding,
+	tinst *templates.TemplatedInstance, hierarchy *Hierarchy) error {
+
+	description := templatedBindingDescription{
+		TemplatedBinding: setKind(tbnd).(*templates.TemplatedBinding),
+		Instance:         tinst,
+		Hierarchy:        hierarchy,
+	}
+	return writeFormatted(w, f, description, []string{objectName(tbnd)})
+}
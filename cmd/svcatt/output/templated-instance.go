@@ -8,20 +8,33 @@ import (
 	"io"
 
 	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 )
 
 // WriteTemplatedInstanceList prints a list of templated instances.
 func WriteTemplatedInstanceList(w io.Writer, tinsts ...templates.TemplatedInstance) {
+	writeTemplatedInstanceTable(w, true, tinsts...)
+}
+
+// WriteTemplatedInstanceRow prints a templated instance as a row of the list,
+// without its header, as it changes while being watched.
+func WriteTemplatedInstanceRow(w io.Writer, tinst *templates.TemplatedInstance) {
+	writeTemplatedInstanceTable(w, false, *tinst)
+}
+
+func writeTemplatedInstanceTable(w io.Writer, header bool, tinsts ...templates.TemplatedInstance) {
 	t := output.NewListTable(w)
-	t.SetHeader([]string{
-		"Name",
-		"Namespace",
-		"Service Type",
-		"Class",
-		"Plan",
-		"Status",
-	})
+	if header {
+		t.SetHeader([]string{
+			"Name",
+			"Namespace",
+			"Service Type",
+			"Class",
+			"Plan",
+			"Status",
+		})
+	}
 
 	for _, tinst := range tinsts {
 		t.Append([]string{
@@ -29,14 +42,23 @@ func WriteTemplatedInstanceList(w io.Writer, tinsts ...templates.TemplatedInstan
 			tinst.Namespace,
 			tinst.Spec.ServiceType,
 			tinst.Spec.ClusterServiceClassExternalName,
-			tinst.Spec.ClusterServicePlanExternalName,
-			"",
+			templatedInstancePlan(&tinst),
+			builder.GetStatus(tinst.Status.Conditions),
 		})
 	}
 
 	t.Render()
 }
 
+// templatedInstancePlan is the plan requested by an instance, or the plan that it
+// resolved to when the plan came from its templates.
+func templatedInstancePlan(tinst *templates.TemplatedInstance) string {
+	if tinst.Spec.ClusterServicePlanExternalName != "" {
+		return tinst.Spec.ClusterServicePlanExternalName
+	}
+	return tinst.Status.ResolvedPlan.Name
+}
+
 // WriteTemplatedInstanceDetails prints a templated instance.
 func WriteTemplatedInstanceDetails(w io.Writer, tinst *templates.TemplatedInstance) {
 	t := output.NewDetailsTable(w)
@@ -44,10 +66,10 @@ func WriteTemplatedInstanceDetails(w io.Writer, tinst *templates.TemplatedInstan
 	t.AppendBulk([][]string{
 		{"Name:", tinst.Name},
 		{"Namespace:", tinst.Namespace},
-		{"Status:", ""},
+		{"Status:", builder.GetStatus(tinst.Status.Conditions)},
 		{"Service Type:", tinst.Spec.ServiceType},
 		{"Class:", tinst.Spec.ClusterServiceClassExternalName},
-		{"Plan:", tinst.Spec.ClusterServicePlanExternalName},
+		{"Plan:", templatedInstancePlan(tinst)},
 	})
 
 	t.Render()
@@ -60,7 +82,30 @@ func WriteParentTemplatedInstance(w io.Writer, tinst *templates.TemplatedInstanc
 	t.AppendBulk([][]string{
 		{"Name:", tinst.Name},
 		{"Namespace:", tinst.Namespace},
-		{"Status:", ""},
+		{"Status:", builder.GetStatus(tinst.Status.Conditions)},
 	})
 	t.Render()
 }
+
+// templatedInstanceDescription is the structured output of describe templated-instance.
+type templatedInstanceDescription struct {
+	*templates.TemplatedInstance
+	Bindings  []templates.TemplatedBinding `json:"bindings"`
+	Hierarchy *Hierarchy                   `json:"hierarchy,omitempty"`
+}
+
+// WriteTemplatedInstanceDescription prints a templated instance with its bindings,
+// and its parent hierarchy when it was traversed, in the format.
+func WriteTemplatedInstanceDescription(w io.Writer, f Format, tinst *templates.TemplatedInstance,
+	bindings []templates.TemplatedBinding, hierarchy *Hierarchy) error {
+
+	if bindings == nil {
+		bindings = []templates.TemplatedBinding{}
+	}
+	description := templatedInstanceDescription{
+		TemplatedInstance: setKind(tinst).(*templates.TemplatedInstance),
+		Bindings:          bindings,
+		Hierarchy:         hierarchy,
+	}
+	return writeFormatted(w, f, description, []string{objectName(tinst)})
+}
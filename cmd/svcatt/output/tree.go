@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+)
+
+// WriteTree prints the ownership tree of a templated resource, with the status,
+// contributing templates and last event of each resource.
+func WriteTree(w io.Writer, f Format, root *svcatt.TreeNode) error {
+	if !f.IsTable() {
+		return writeFormatted(w, f, root, []string{strings.ToLower(root.Kind) + "/" + root.Name})
+	}
+
+	writeTreeNode(w, root, "", "")
+	return nil
+}
+
+// writeTreeNode prints a node after the prefix, and its details and children after the
+// prefix of its children.
+func writeTreeNode(w io.Writer, node *svcatt.TreeNode, prefix, childPrefix string) {
+	fmt.Fprintf(w, "%s%s  %s\n", prefix, treeNodeName(node), node.Status)
+
+	details := childPrefix + "│   "
+	if len(node.Children) == 0 {
+		details = childPrefix + "    "
+	}
+	if len(node.Templates) > 0 {
+		fmt.Fprintf(w, "%sTemplates: %s\n", details, strings.Join(node.Templates, ", "))
+	}
+	if node.LastEvent != "" {
+		fmt.Fprintf(w, "%sLast Event: %s\n", details, node.LastEvent)
+	}
+
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			writeTreeNode(w, child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			writeTreeNode(w, child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+func treeNodeName(node *svcatt.TreeNode) string {
+	return fmt.Sprintf("%s/%s", node.Kind, node.Name)
+}
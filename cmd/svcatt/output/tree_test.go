@@ -0,0 +1,42 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+)
+
+func TestWriteTree(t *testing.T) {
+	root := &svcatt.TreeNode{
+		Kind:      "TemplatedInstance",
+		Name:      "db",
+		Status:    "Ready",
+		Templates: []string{"cluster template mysqldb"},
+		Children: []*svcatt.TreeNode{
+			{Kind: "ServiceInstance", Name: "db", Status: "Ready", LastEvent: "ProvisionedSuccessfully: done"},
+			{Kind: "TemplatedBinding", Name: "db", Status: "Pending", Children: []*svcatt.TreeNode{
+				{Kind: "ServiceBinding", Name: "db", Status: "Missing"},
+			}},
+		},
+	}
+
+	want := `TemplatedInstance/db  Ready
+│   Templates: cluster template mysqldb
+├── ServiceInstance/db  Ready
+│       Last Event: ProvisionedSuccessfully: done
+└── TemplatedBinding/db  Pending
+    └── ServiceBinding/db  Missing
+`
+
+	var buf bytes.Buffer
+	if err := WriteTree(&buf, Format{}, root); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Fatalf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
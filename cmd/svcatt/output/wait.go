@@ -0,0 +1,14 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteWaitProgress prints the status of a resource that is being waited on.
+func WriteWaitProgress(w io.Writer, kind, name, status string) {
+	fmt.Fprintf(w, "%s/%s: %s\n", kind, name, status)
+}
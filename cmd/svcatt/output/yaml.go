@@ -0,0 +1,30 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcattoutput
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// writeYAML writes the given obj to the given Writer in YAML format, indented
+// n spaces
+func writeYAML(w io.Writer, obj interface{}, n int) {
+	yBytes, err := yaml.Marshal(obj)
+	if err != nil {
+		fmt.Fprintf(w, "err marshaling yaml: %v\n", err)
+		return
+	}
+	y := string(yBytes)
+	if n > 0 {
+		indent := strings.Repeat(" ", n)
+		y = indent + strings.Replace(y, "\n", "\n"+indent, -1)
+		y = strings.TrimRight(y, " ")
+	}
+
+	fmt.Fprint(w, y)
+}
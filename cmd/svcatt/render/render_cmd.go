@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package render
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type renderCmd struct {
+	*svcattcommand.Context
+	ns        string
+	filenames []string
+}
+
+// NewRenderCmd builds a "svcatt render" command
+func NewRenderCmd(cxt *svcattcommand.Context) *cobra.Command {
+	renderCmd := &renderCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Resolves templated resources defined in local files and prints the resources that would be created, without connecting to a cluster",
+		Example: `
+  svcatt render -f templates/ -f app.yaml
+  svcatt render -f app.yaml --namespace dev
+`,
+		Annotations: map[string]string{svcattcommand.OfflineAnnotation: "true"},
+		PreRunE:     command.PreRunE(renderCmd),
+		RunE:        command.RunE(renderCmd),
+	}
+	cmd.Flags().StringSliceVarP(&renderCmd.filenames, "filename", "f", nil,
+		"File or directory containing templates and templated resources. May be specified multiple times.")
+	cmd.Flags().StringVarP(
+		&renderCmd.ns,
+		"namespace",
+		"n",
+		"default",
+		"The namespace of resources that do not specify one",
+	)
+
+	return cmd
+}
+
+func (c *renderCmd) Validate(args []string) error {
+	if len(c.filenames) == 0 {
+		return fmt.Errorf("at least one --filename is required")
+	}
+	return nil
+}
+
+func (c *renderCmd) Run() error {
+	objects, err := svcatt.LoadManifests(c.filenames, c.ns)
+	if err != nil {
+		return err
+	}
+
+	sdk, err := servicecatalogtempltesdk.NewOffline(objects...)
+	if err != nil {
+		return err
+	}
+
+	var tinsts []*templates.TemplatedInstance
+	var tbnds []*templates.TemplatedBinding
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *templates.TemplatedInstance:
+			tinsts = append(tinsts, o)
+		case *templates.TemplatedBinding:
+			tbnds = append(tbnds, o)
+		}
+	}
+	sort.Slice(tinsts, func(i, j int) bool {
+		return tinsts[i].Namespace+"/"+tinsts[i].Name < tinsts[j].Namespace+"/"+tinsts[j].Name
+	})
+	sort.Slice(tbnds, func(i, j int) bool {
+		return tbnds[i].Namespace+"/"+tbnds[i].Name < tbnds[j].Namespace+"/"+tbnds[j].Name
+	})
+
+	instances := make([]*servicecatalogtempltesdk.InstanceResolution, 0, len(tinsts))
+	for _, tinst := range tinsts {
+		res, err := sdk.ResolveInstance(tinst)
+		if err != nil {
+			return fmt.Errorf("unable to render templated instance %s/%s (%s)", tinst.Namespace, tinst.Name, err)
+		}
+		instances = append(instances, res)
+	}
+
+	bindings := make([]*servicecatalogtempltesdk.BindingResolution, 0, len(tbnds))
+	for _, tbnd := range tbnds {
+		res, err := sdk.ResolveBinding(tbnd)
+		if err != nil {
+			return fmt.Errorf("unable to render templated binding %s/%s (%s)", tbnd.Namespace, tbnd.Name, err)
+		}
+		bindings = append(bindings, res)
+	}
+
+	svcattoutput.WriteRendering(c.Output, instances, bindings)
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicetype
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type describeCmd struct {
+	*svcattcommand.Context
+	ns          string
+	serviceType string
+	output      string
+	format      svcattoutput.Format
+}
+
+// NewDescribeCmd builds a "svcat describe service-type" command
+func NewDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
+	describeCmd := &describeCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "service-type NAME",
+		Aliases: []string{"service-types", "servicetype", "servicetypes", "type"},
+		Short:   "Show the effective templates of a service type in a namespace",
+		Long: `Show the effective templates of a service type in a namespace.
+
+The broker, cluster and namespace templates of the service type are merged like
+they are when resolving a templated instance or binding. Each value is listed
+with the template that it came from, followed by warnings about ambiguous broker
+templates and settings that are shadowed by another template.`,
+		Example: `
+  svcat describe service-type mysqldb
+  svcat describe service-type mysqldb --namespace teamA -o json
+`,
+		PreRunE: command.PreRunE(describeCmd),
+		RunE:    command.RunE(describeCmd),
+	}
+	cmd.Flags().StringVarP(
+		&describeCmd.ns,
+		"namespace",
+		"n",
+		"",
+		"The namespace whose templates are merged",
+	)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &describeCmd.output)
+	return cmd
+}
+
+func (c *describeCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a service type is required")
+	}
+	c.serviceType = args[0]
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
+}
+
+func (c *describeCmd) Run() error {
+	description, err := c.App().DescribeServiceType(c.ns, c.serviceType)
+	if err != nil {
+		return err
+	}
+
+	return svcattoutput.WriteServiceTypeDescription(c.Output, c.format, description)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package status
+
+import (
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type statusCmd struct {
+	*svcattcommand.Context
+	ns     string
+	output string
+	format svcattoutput.Format
+}
+
+// NewStatusCmd builds a "svcat status" command
+func NewStatusCmd(cxt *svcattcommand.Context) *cobra.Command {
+	statusCmd := &statusCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Summarize the templated instances in the cluster",
+		Long: `Summarize the templated instances in the cluster.
+
+Instances are grouped by service type, provider and the broker of the class that
+they resolved to, and counted by status: ready, pending or failed.`,
+		Example: `
+  svcat status
+  svcat status --namespace teamA
+  svcat status -o json
+`,
+		PreRunE: command.PreRunE(statusCmd),
+		RunE:    command.RunE(statusCmd),
+	}
+	cmd.Flags().StringVarP(
+		&statusCmd.ns,
+		"namespace",
+		"n",
+		"",
+		"Only summarize the instances in the namespace. Defaults to all namespaces",
+	)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &statusCmd.output)
+	return cmd
+}
+
+func (c *statusCmd) Validate(args []string) error {
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
+}
+
+func (c *statusCmd) Run() error {
+	summaries, err := c.App().SummarizeTemplatedInstances(c.ns)
+	if err != nil {
+		return err
+	}
+
+	return svcattoutput.WriteTemplatedInstanceSummaries(c.Output, c.format, summaries)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
 	"github.com/spf13/cobra"
@@ -23,6 +24,8 @@ type bindCmd struct {
 	params       map[string]string
 	rawSecrets   []string
 	secrets      map[string]string
+	dryRun       bool
+	svcattcommand.WaitFlags
 }
 
 // NewBindCmd builds a "svcat bind" command
@@ -34,6 +37,8 @@ func NewBindCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Example: `
   svcat bind wordpress
   svcat bind wordpress-mysql-instance --name wordpress-mysql-binding --secret-name wordpress-mysql-secret
+  svcat bind wordpress-mysql-instance --dry-run
+  svcat bind wordpress-mysql-instance --wait
 `,
 		PreRunE: command.PreRunE(bindCmd),
 		RunE:    command.RunE(bindCmd),
@@ -63,6 +68,9 @@ func NewBindCmd(cxt *svcattcommand.Context) *cobra.Command {
 		"Additional parameter to use when binding the instance, format: NAME=VALUE")
 	cmd.Flags().StringSliceVarP(&bindCmd.rawSecrets, "secret", "s", nil,
 		"Additional parameter, whose value is stored in a secret, to use when binding the instance, format: SECRET[KEY]")
+	cmd.Flags().BoolVar(&bindCmd.dryRun, "dry-run", false,
+		"Print the service binding that would be created, and the templates used to resolve it, without creating anything")
+	svcattcommand.AddWaitFlags(cmd.Flags(), &bindCmd.WaitFlags)
 
 	return cmd
 }
@@ -77,6 +85,10 @@ func (c *bindCmd) Validate(args []string) error {
 		c.ns = c.App().CurrentNamespace
 	}
 
+	if c.Wait && c.dryRun {
+		return fmt.Errorf("--wait cannot be used with --dry-run")
+	}
+
 	var err error
 	c.params, err = parameters.ParseVariableAssignments(c.rawParams)
 	if err != nil {
@@ -92,9 +104,22 @@ func (c *bindCmd) Validate(args []string) error {
 }
 
 func (c *bindCmd) Run() error {
+	if c.dryRun {
+		return c.dryRunBind()
+	}
 	return c.bind()
 }
 
+func (c *bindCmd) dryRunBind() error {
+	res, err := c.App().DryRunBind(c.ns, c.bindingName, c.instanceName, c.secretName, c.params, c.secrets)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteBindingResolution(c.Output, res)
+	return nil
+}
+
 func (c *bindCmd) bind() error {
 	tbnd, err := c.App().Bind(c.ns, c.bindingName, c.instanceName, c.secretName, c.params, c.secrets)
 	if err != nil {
@@ -102,5 +127,9 @@ func (c *bindCmd) bind() error {
 	}
 
 	svcattoutput.WriteTemplatedBindingDetails(c.Output, tbnd)
+
+	if c.Wait {
+		return c.WaitForTemplatedBinding(tbnd.Namespace, tbnd.Name, svcatt.WaitForReady, c.Timeout)
+	}
 	return nil
 }
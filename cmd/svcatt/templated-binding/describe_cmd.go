@@ -8,8 +8,10 @@ import (
 
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +20,8 @@ type describeCmd struct {
 	ns       string
 	name     string
 	traverse bool
+	output   string
+	format   svcattoutput.Format
 }
 
 // NewDescribeCmd builds a "svcat describe templated-binding" command
@@ -29,6 +33,7 @@ func NewDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Short:   "Show details of a specific templated binding",
 		Example: `
   svcat describe templated-binding wordpress-mysql-binding
+  svcat describe templated-binding wordpress-mysql-binding -o jsonpath='{.status.conditions}'
 `,
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -47,6 +52,7 @@ func NewDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
 		false,
 		"Whether or not to traverse from binding -> instance -> class/plan -> broker",
 	)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &describeCmd.output)
 	return cmd
 }
 
@@ -60,7 +66,9 @@ func (c *describeCmd) Validate(args []string) error {
 		c.ns = c.App().CurrentNamespace
 	}
 
-	return nil
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *describeCmd) Run() error {
@@ -73,17 +81,29 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
-	svcattoutput.WriteTemplatedBindingDetails(c.Output, tbnd)
-
+	var tinst *templates.TemplatedInstance
+	var hierarchy *svcattoutput.Hierarchy
 	if c.traverse {
-		tinst, class, plan, broker, err := c.App().TemplatedBindingParentHierarchy(tbnd)
+		var class *svcat.ClusterServiceClass
+		var plan *svcat.ClusterServicePlan
+		var broker *svcat.ClusterServiceBroker
+		tinst, class, plan, broker, err = c.App().TemplatedBindingParentHierarchy(tbnd)
 		if err != nil {
 			return fmt.Errorf("unable to traverse up the templated binding hierarchy (%s)", err)
 		}
+		hierarchy = &svcattoutput.Hierarchy{Class: class, Plan: plan, Broker: broker}
+	}
+
+	if !c.format.IsTable() {
+		return svcattoutput.WriteTemplatedBindingDescription(c.Output, c.format, tbnd, tinst, hierarchy)
+	}
+
+	svcattoutput.WriteTemplatedBindingDetails(c.Output, tbnd)
+	if hierarchy != nil {
 		svcattoutput.WriteParentTemplatedInstance(c.Output, tinst)
-		output.WriteParentClass(c.Output, class)
-		output.WriteParentPlan(c.Output, plan)
-		output.WriteParentBroker(c.Output, broker)
+		output.WriteParentClass(c.Output, hierarchy.Class)
+		output.WriteParentPlan(c.Output, hierarchy.Plan)
+		output.WriteParentBroker(c.Output, hierarchy.Broker)
 	}
 
 	return nil
@@ -4,10 +4,16 @@
 package templatedbinding
 
 import (
+	"fmt"
+
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
@@ -15,6 +21,11 @@ type getCmd struct {
 	ns            string
 	name          string
 	allNamespaces bool
+	watch         bool
+	filters       svcattcommand.FilterFlags
+	listOpts      servicecatalogtempltesdk.TemplatedListOptions
+	output        string
+	format        svcattoutput.Format
 }
 
 // NewGetCmd builds a "svcat get templated-bindings" command
@@ -27,8 +38,11 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Example: `
   svcat get templated-bindings
   svcat get templated-bindings --all-namespaces
+  svcat get templated-bindings --type mysqldb --status pending
+  svcat get templated-bindings -w
   svcat get templated-binding wordpress-mysql-binding
   svcat get templated-binding -n ci concourse-postgres-binding
+  svcat get templated-binding wordpress-mysql-binding -o yaml
 `,
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -48,19 +62,38 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
 		false,
 		"List all bindings across namespaces",
 	)
+	cmd.Flags().BoolVarP(
+		&getCmd.watch,
+		"watch",
+		"w",
+		false,
+		"After listing the bindings, print each one again whenever it changes",
+	)
+	svcattcommand.AddFilterFlags(cmd.Flags(), &getCmd.filters)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &getCmd.output)
 	return cmd
 }
 
 func (c *getCmd) Validate(args []string) error {
 	if len(args) > 0 {
 		c.name = args[0]
+		if c.filters.IsSet() {
+			return fmt.Errorf("--selector, --type and --status cannot be used with a name")
+		}
 	}
 
 	if c.ns == "" {
 		c.ns = c.App().CurrentNamespace
 	}
 
-	return nil
+	var err error
+	c.listOpts, err = c.filters.ListOptions()
+	if err != nil {
+		return err
+	}
+
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *getCmd) Run() error {
@@ -76,11 +109,23 @@ func (c *getCmd) getAll() error {
 		c.ns = ""
 	}
 
-	tbnds, err := c.App().RetrieveTemplatedBindings(c.ns)
+	tbnds, err := c.App().ListTemplatedBindings(c.ns, c.listOpts)
 	if err != nil {
 		return err
 	}
 
+	if c.watch {
+		return c.watchChanges(tbnds.ResourceVersion, tbnds.Items...)
+	}
+
+	if !c.format.IsTable() {
+		objs := make([]runtime.Object, 0, len(tbnds.Items))
+		for i := range tbnds.Items {
+			objs = append(objs, &tbnds.Items[i])
+		}
+		return svcattoutput.WriteObjectList(c.Output, c.format, objs...)
+	}
+
 	svcattoutput.WriteTemplatedBindingList(c.Output, tbnds.Items...)
 	return nil
 }
@@ -91,6 +136,37 @@ func (c *getCmd) get() error {
 		return err
 	}
 
+	if c.watch {
+		return c.watchChanges(tbnd.ResourceVersion, *tbnd)
+	}
+
+	if !c.format.IsTable() {
+		return svcattoutput.WriteObject(c.Output, c.format, tbnd)
+	}
+
 	svcattoutput.WriteTemplatedBindingList(c.Output, *tbnd)
 	return nil
 }
+
+// watchChanges prints the bindings, and then each one that matches the filters whenever it changes,
+// until the server closes the watch.
+func (c *getCmd) watchChanges(resourceVersion string, tbnds ...templates.TemplatedBinding) error {
+	if c.format.IsTable() {
+		svcattoutput.WriteTemplatedBindingList(c.Output, tbnds...)
+	} else {
+		for i := range tbnds {
+			if err := svcattoutput.WriteObject(c.Output, c.format, &tbnds[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.App().WatchTemplatedBindings(c.ns, c.name, resourceVersion, c.listOpts,
+		func(_ watch.EventType, tbnd *templates.TemplatedBinding) (bool, error) {
+			if !c.format.IsTable() {
+				return false, svcattoutput.WriteObject(c.Output, c.format, tbnd)
+			}
+			svcattoutput.WriteTemplatedBindingRow(c.Output, tbnd)
+			return false, nil
+		})
+}
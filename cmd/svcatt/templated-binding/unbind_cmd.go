@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,7 @@ type unbindCmd struct {
 	ns           string
 	instanceName string
 	bindingName  string
+	svcattcommand.WaitFlags
 }
 
 // NewUnbindCmd builds a "svcat unbind" command
@@ -28,6 +30,7 @@ func NewUnbindCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Example: `
   svcat unbind wordpress-mysql-instance
   svcat unbind --name wordpress-mysql-binding
+  svcat unbind wordpress-mysql-instance --wait
 `,
 		PreRunE: command.PreRunE(unbindCmd),
 		RunE:    command.RunE(unbindCmd),
@@ -46,6 +49,7 @@ func NewUnbindCmd(cxt *svcattcommand.Context) *cobra.Command {
 		"",
 		"The name of the binding to remove",
 	)
+	svcattcommand.AddWaitFlags(cmd.Flags(), &unbindCmd.WaitFlags)
 	return cmd
 }
 
@@ -74,14 +78,28 @@ func (c *unbindCmd) Run() error {
 
 func (c *unbindCmd) deleteTemplatedBinding() error {
 	err := c.App().DeleteTemplatedBinding(c.ns, c.bindingName)
-	if err == nil {
-		svcattoutput.WriteDeletedTemplatedBindingName(c.Output, c.bindingName)
+	if err != nil {
+		return err
 	}
-	return err
+	svcattoutput.WriteDeletedTemplatedBindingName(c.Output, c.bindingName)
+
+	if c.Wait {
+		return c.WaitForTemplatedBinding(c.ns, c.bindingName, svcatt.WaitForDeleted, c.Timeout)
+	}
+	return nil
 }
 
 func (c *unbindCmd) unbindTemplatedInstance() error {
 	bindings, err := c.App().Unbind(c.ns, c.instanceName)
 	svcattoutput.WriteDeletedTemplatedBindingNames(c.Output, bindings)
-	return err
+	if err != nil || !c.Wait {
+		return err
+	}
+
+	for _, tbnd := range bindings {
+		if err := c.WaitForTemplatedBinding(tbnd.Namespace, tbnd.Name, svcatt.WaitForDeleted, c.Timeout); err != nil {
+			return err
+		}
+	}
+	return nil
 }
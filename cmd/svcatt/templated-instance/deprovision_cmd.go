@@ -7,6 +7,8 @@ import (
 	"fmt"
 
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +17,10 @@ type deprovisonCmd struct {
 	*svcattcommand.Context
 	ns           string
 	instanceName string
+	cascade      bool
+	force        bool
+	dryRun       bool
+	svcattcommand.WaitFlags
 }
 
 // NewDeprovisionCmd builds a "svcat deprovision" command
@@ -25,12 +31,22 @@ func NewDeprovisionCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Short: "Deletes an instance of a service",
 		Example: `
   svcat deprovision wordpress-mysql-instance
+  svcat deprovision wordpress-mysql-instance --wait
+  svcat deprovision wordpress-mysql-instance --cascade --dry-run
+  svcat deprovision wordpress-mysql-instance --cascade --force
 `,
 		PreRunE: command.PreRunE(deprovisonCmd),
 		RunE:    command.RunE(deprovisonCmd),
 	}
 	cmd.Flags().StringVarP(&deprovisonCmd.ns, "namespace", "n", "",
 		"The namespace of the resource")
+	cmd.Flags().BoolVar(&deprovisonCmd.cascade, "cascade", false,
+		"Remove the bindings to the instance, and wait for them to be deleted, before deleting the instance")
+	cmd.Flags().BoolVar(&deprovisonCmd.force, "force", false,
+		"Remove the bindings with --cascade even when workloads reference their secrets")
+	cmd.Flags().BoolVar(&deprovisonCmd.dryRun, "dry-run", false,
+		"Print the bindings that would be affected, and the workloads that reference their secrets, without deleting anything")
+	svcattcommand.AddWaitFlags(cmd.Flags(), &deprovisonCmd.WaitFlags)
 	return cmd
 }
 
@@ -44,6 +60,10 @@ func (c *deprovisonCmd) Validate(args []string) error {
 		c.ns = c.App().CurrentNamespace
 	}
 
+	if c.Wait && c.dryRun {
+		return fmt.Errorf("--wait cannot be used with --dry-run")
+	}
+
 	return nil
 }
 
@@ -52,5 +72,46 @@ func (c *deprovisonCmd) Run() error {
 }
 
 func (c *deprovisonCmd) deprovision() error {
-	return c.App().Deprovision(c.ns, c.instanceName)
+	// Only --cascade removes the bindings, and with them the secrets that workloads may use
+	if c.dryRun || (c.cascade && !c.force) {
+		plan, err := c.App().PlanDeprovision(c.ns, c.instanceName)
+		if err != nil {
+			return err
+		}
+
+		if c.dryRun {
+			svcattoutput.WriteDeprovisionPlan(c.Output, plan, c.cascade)
+			return nil
+		}
+
+		svcattoutput.WriteUnscannedWorkloads(c.Output, plan.Unscanned)
+		if len(plan.Consumers) > 0 {
+			fmt.Fprintf(c.Output, "Workloads still reference the secrets of %s/%s:\n", c.ns, c.instanceName)
+			svcattoutput.WriteSecretConsumers(c.Output, plan.Consumers)
+			return fmt.Errorf("instance %s/%s is in use, use --force to deprovision it anyway", c.ns, c.instanceName)
+		}
+	}
+
+	if c.cascade {
+		bindings, err := c.App().CascadeDeprovision(c.ns, c.instanceName, c.Timeout, func(binding string) svcatt.WaitProgress {
+			return func(status string) {
+				svcattoutput.WriteWaitProgress(c.Output, "templated-binding", binding, status)
+			}
+		})
+		svcattoutput.WriteDeletedTemplatedBindingNames(c.Output, bindings)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := c.App().Deprovision(c.ns, c.instanceName)
+		if err != nil {
+			return err
+		}
+	}
+	svcattoutput.WriteDeletedTemplatedInstanceName(c.Output, c.instanceName)
+
+	if c.Wait {
+		return c.WaitForTemplatedInstance(c.ns, c.instanceName, svcatt.WaitForDeleted, c.Timeout)
+	}
+	return nil
 }
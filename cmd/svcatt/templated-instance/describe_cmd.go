@@ -18,6 +18,8 @@ type describeCmd struct {
 	ns       string
 	name     string
 	traverse bool
+	output   string
+	format   svcattoutput.Format
 }
 
 // NewDescribeCmd builds a "svcat describe templated-instance" command
@@ -29,6 +31,7 @@ func NewDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Short:   "Show details of a specific templated instance",
 		Example: `
   svcat describe templated-instance wordpress-mysql-instance
+  svcat describe templated-instance wordpress-mysql-instance --traverse -o json
 `,
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -47,6 +50,7 @@ func NewDescribeCmd(cxt *svcattcommand.Context) *cobra.Command {
 		false,
 		"Whether or not to traverse from instance -> class/plan -> broker",
 	)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &describeCmd.output)
 	return cmd
 }
 
@@ -59,7 +63,10 @@ func (c *describeCmd) Validate(args []string) error {
 	if c.ns == "" {
 		c.ns = c.App().CurrentNamespace
 	}
-	return nil
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *describeCmd) Run() error {
@@ -72,22 +79,30 @@ func (c *describeCmd) describe() error {
 		return err
 	}
 
-	svcattoutput.WriteTemplatedInstanceDetails(c.Output, instance)
-
 	bindings, err := c.App().RetrieveTemplatedBindingsByInstance(instance)
 	if err != nil {
 		return err
 	}
-	svcattoutput.WriteAssociatedTemplatedBindings(c.Output, bindings)
 
+	var hierarchy *svcattoutput.Hierarchy
 	if c.traverse {
 		class, plan, broker, err := c.App().TemplatedInstanceParentHierarchy(instance)
 		if err != nil {
 			return fmt.Errorf("unable to traverse up the templated instance hierarchy (%s)", err)
 		}
-		output.WriteParentClass(c.Output, class)
-		output.WriteParentPlan(c.Output, plan)
-		output.WriteParentBroker(c.Output, broker)
+		hierarchy = &svcattoutput.Hierarchy{Class: class, Plan: plan, Broker: broker}
+	}
+
+	if !c.format.IsTable() {
+		return svcattoutput.WriteTemplatedInstanceDescription(c.Output, c.format, instance, bindings, hierarchy)
+	}
+
+	svcattoutput.WriteTemplatedInstanceDetails(c.Output, instance)
+	svcattoutput.WriteAssociatedTemplatedBindings(c.Output, bindings)
+	if hierarchy != nil {
+		output.WriteParentClass(c.Output, hierarchy.Class)
+		output.WriteParentPlan(c.Output, hierarchy.Plan)
+		output.WriteParentBroker(c.Output, hierarchy.Broker)
 	}
 
 	return nil
@@ -4,10 +4,16 @@
 package templatedinstance
 
 import (
+	"fmt"
+
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
@@ -15,6 +21,11 @@ type getCmd struct {
 	ns            string
 	name          string
 	allNamespaces bool
+	watch         bool
+	filters       svcattcommand.FilterFlags
+	listOpts      servicecatalogtempltesdk.TemplatedListOptions
+	output        string
+	format        svcattoutput.Format
 }
 
 // NewGetCmd builds a "svcat get templated-instances" command
@@ -27,8 +38,12 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
 		Example: `
   svcat get templated-instances
   svcat get templated-instances --all-namespaces
+  svcat get templated-instances --all-namespaces --type mysqldb --status failed
+  svcat get templated-instances -l app=wordpress
+  svcat get templated-instances --watch
   svcat get templated-instances wordpress-mysql-instance
   svcat get templated-instances -n ci concourse-postgres-instance
+  svcat get templated-instances -o jsonpath='{.items[*].status.conditions}'
 `,
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -47,19 +62,38 @@ func NewGetCmd(cxt *svcattcommand.Context) *cobra.Command {
 		false,
 		"List all resources across namespaces",
 	)
+	cmd.Flags().BoolVarP(
+		&getCmd.watch,
+		"watch",
+		"w",
+		false,
+		"After listing the instances, print each one again whenever it changes",
+	)
+	svcattcommand.AddFilterFlags(cmd.Flags(), &getCmd.filters)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &getCmd.output)
 	return cmd
 }
 
 func (c *getCmd) Validate(args []string) error {
 	if len(args) > 0 {
 		c.name = args[0]
+		if c.filters.IsSet() {
+			return fmt.Errorf("--selector, --type and --status cannot be used with a name")
+		}
 	}
 
 	if c.ns == "" {
 		c.ns = c.App().CurrentNamespace
 	}
 
-	return nil
+	var err error
+	c.listOpts, err = c.filters.ListOptions()
+	if err != nil {
+		return err
+	}
+
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
 }
 
 func (c *getCmd) Run() error {
@@ -75,11 +109,23 @@ func (c *getCmd) getAll() error {
 		c.ns = ""
 	}
 
-	tinsts, err := c.App().RetrieveTemplatedInstances(c.ns)
+	tinsts, err := c.App().ListTemplatedInstances(c.ns, c.listOpts)
 	if err != nil {
 		return err
 	}
 
+	if c.watch {
+		return c.watchChanges(tinsts.ResourceVersion, tinsts.Items...)
+	}
+
+	if !c.format.IsTable() {
+		objs := make([]runtime.Object, 0, len(tinsts.Items))
+		for i := range tinsts.Items {
+			objs = append(objs, &tinsts.Items[i])
+		}
+		return svcattoutput.WriteObjectList(c.Output, c.format, objs...)
+	}
+
 	svcattoutput.WriteTemplatedInstanceList(c.Output, tinsts.Items...)
 	return nil
 }
@@ -90,6 +136,37 @@ func (c *getCmd) get() error {
 		return err
 	}
 
+	if c.watch {
+		return c.watchChanges(tinst.ResourceVersion, *tinst)
+	}
+
+	if !c.format.IsTable() {
+		return svcattoutput.WriteObject(c.Output, c.format, tinst)
+	}
+
 	svcattoutput.WriteTemplatedInstanceList(c.Output, *tinst)
 	return nil
 }
+
+// watchChanges prints the instances, and then each one that matches the filters whenever it changes,
+// until the server closes the watch.
+func (c *getCmd) watchChanges(resourceVersion string, tinsts ...templates.TemplatedInstance) error {
+	if c.format.IsTable() {
+		svcattoutput.WriteTemplatedInstanceList(c.Output, tinsts...)
+	} else {
+		for i := range tinsts {
+			if err := svcattoutput.WriteObject(c.Output, c.format, &tinsts[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.App().WatchTemplatedInstances(c.ns, c.name, resourceVersion, c.listOpts,
+		func(_ watch.EventType, tinst *templates.TemplatedInstance) (bool, error) {
+			if !c.format.IsTable() {
+				return false, svcattoutput.WriteObject(c.Output, c.format, tinst)
+			}
+			svcattoutput.WriteTemplatedInstanceRow(c.Output, tinst)
+			return false, nil
+		})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
 	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
 	"github.com/spf13/cobra"
@@ -26,6 +27,10 @@ type provisonCmd struct {
 	params       interface{}
 	rawSecrets   []string
 	secrets      map[string]string
+	dryRun       bool
+	bind         bool
+	secretName   string
+	svcattcommand.WaitFlags
 }
 
 // NewProvisionCmd builds a "svcat provision" command
@@ -54,6 +59,10 @@ func NewProvisionCmd(cxt *svcattcommand.Context) *cobra.Command {
     ]
   }
   svcat provision wordpress-mysql-instance --class mysqldb --plan free
+  svcat provision mysql-instance --type mysqldb --dry-run
+  svcat provision mysql-instance --type mysqldb --wait --timeout 10m
+  svcat provision mysql-instance --type mysqldb --bind
+  svcat provision mysql-instance --type mysqldb --bind --secret-name mysql-secret --wait
 '
 `,
 		PreRunE: command.PreRunE(provisionCmd),
@@ -73,6 +82,13 @@ func NewProvisionCmd(cxt *svcattcommand.Context) *cobra.Command {
 		"Additional parameter, whose value is stored in a secret, to use when provisioning the service, format: SECRET[KEY]")
 	cmd.Flags().StringVar(&provisionCmd.jsonParams, "params-json", "",
 		"Additional parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().BoolVar(&provisionCmd.dryRun, "dry-run", false,
+		"Print the service instance that would be created, and the templates used to resolve it, without creating anything")
+	cmd.Flags().BoolVar(&provisionCmd.bind, "bind", false,
+		"Also create a binding to the instance, with the same name as the instance")
+	cmd.Flags().StringVar(&provisionCmd.secretName, "secret-name", "",
+		"The name of the secret created by --bind. Defaults to the name of the instance")
+	svcattcommand.AddWaitFlags(cmd.Flags(), &provisionCmd.WaitFlags)
 	return cmd
 }
 
@@ -96,6 +112,18 @@ func (c *provisonCmd) Validate(args []string) error {
 		}
 	}
 
+	if c.Wait && c.dryRun {
+		return fmt.Errorf("--wait cannot be used with --dry-run")
+	}
+
+	if c.secretName != "" && !c.bind {
+		return fmt.Errorf("--secret-name requires --bind")
+	}
+
+	if c.bind && c.dryRun {
+		return fmt.Errorf("--bind cannot be used with --dry-run, the binding is resolved against the created instance")
+	}
+
 	var err error
 
 	if c.jsonParams != "" && len(c.rawParams) > 0 {
@@ -123,9 +151,30 @@ func (c *provisonCmd) Validate(args []string) error {
 }
 
 func (c *provisonCmd) Run() error {
+	if c.dryRun {
+		return c.DryRun()
+	}
+	if c.bind {
+		return c.ProvisionAndBind()
+	}
 	return c.Provision()
 }
 
+func (c *provisonCmd) DryRun() error {
+	provider, err := c.App().NamespaceProvider(c.ns)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.App().DryRunProvision(c.ns, c.instanceName, c.serviceType, c.className, c.planName, c.params, c.secrets, provider)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteInstanceResolution(c.Output, res)
+	return nil
+}
+
 func (c *provisonCmd) Provision() error {
 	tinst, err := c.App().Provision(c.ns, c.instanceName, c.serviceType, c.className, c.planName, c.params, c.secrets)
 	if err != nil {
@@ -134,5 +183,29 @@ func (c *provisonCmd) Provision() error {
 
 	svcattoutput.WriteTemplatedInstanceDetails(c.Output, tinst)
 
+	if c.Wait {
+		return c.WaitForTemplatedInstance(tinst.Namespace, tinst.Name, svcatt.WaitForReady, c.Timeout)
+	}
+	return nil
+}
+
+func (c *provisonCmd) ProvisionAndBind() error {
+	tinst, tbnd, err := c.App().ProvisionAndBind(c.ns, c.instanceName, c.serviceType, c.className, c.planName,
+		c.params, c.secrets, c.secretName)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteTemplatedInstanceDetails(c.Output, tinst)
+	fmt.Fprintln(c.Output)
+	svcattoutput.WriteTemplatedBindingDetails(c.Output, tbnd)
+
+	if c.Wait {
+		err = c.WaitForTemplatedInstance(tinst.Namespace, tinst.Name, svcatt.WaitForReady, c.Timeout)
+		if err != nil {
+			return err
+		}
+		return c.WaitForTemplatedBinding(tbnd.Namespace, tbnd.Name, svcatt.WaitForReady, c.Timeout)
+	}
 	return nil
 }
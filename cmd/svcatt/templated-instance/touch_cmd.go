@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package templatedinstance
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type touchCmd struct {
+	*svcattcommand.Context
+	ns           string
+	instanceName string
+}
+
+// NewTouchCmd builds a "svcat touch templated-instance" command
+func NewTouchCmd(cxt *svcattcommand.Context) *cobra.Command {
+	touchCmd := &touchCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "templated-instance NAME",
+		Aliases: []string{"templatedinstance", "tinst"},
+		Short:   "Touch an instance to make the templates controller and service catalog process it again",
+		Long: `Touch templated-instance increments the updateRequests field on the instance, which is
+copied to its service instance. Service catalog then processes the instance's spec again,
+which might do an update, a delete, or nothing.`,
+		Example: `
+  svcat touch templated-instance wordpress-mysql-instance --namespace mynamespace
+`,
+		PreRunE: command.PreRunE(touchCmd),
+		RunE:    command.RunE(touchCmd),
+	}
+	cmd.Flags().StringVarP(&touchCmd.ns, "namespace", "n", "",
+		"The namespace of the resource")
+	return cmd
+}
+
+func (c *touchCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.instanceName = args[0]
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+
+	return nil
+}
+
+func (c *touchCmd) Run() error {
+	const retries = 3
+	return c.App().TouchTemplatedInstance(c.ns, c.instanceName, retries)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package templatedinstance
+
+import (
+	"fmt"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	"github.com/spf13/cobra"
+)
+
+type updateCmd struct {
+	*svcattcommand.Context
+
+	ns           string
+	instanceName string
+	serviceType  string
+	planName     string
+	rawParams    []string
+	jsonParams   string
+	rawSecrets   []string
+	update       servicecatalogtempltesdk.TemplatedInstanceUpdate
+}
+
+// NewUpdateCmd builds a "svcat update templated-instance" command
+func NewUpdateCmd(cxt *svcattcommand.Context) *cobra.Command {
+	updateCmd := &updateCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:     "templated-instance NAME",
+		Aliases: []string{"templatedinstance", "tinst"},
+		Short:   "Change the service type, plan or parameters of an instance",
+		Example: `
+  svcat update templated-instance mysql-instance -p location=westus
+  svcat update templated-instance mysql-instance --plan standard100
+  svcat update templated-instance mysql-instance --type mysqldb-ha
+  svcat update templated-instance mysql-instance -s mysecret[dbparams]
+`,
+		PreRunE: command.PreRunE(updateCmd),
+		RunE:    command.RunE(updateCmd),
+	}
+	cmd.Flags().StringVarP(&updateCmd.ns, "namespace", "n", "",
+		"The namespace of the resource")
+	cmd.Flags().StringVar(&updateCmd.serviceType, "type", "",
+		"Re-resolve the plan and parameters from the templates of another service type. The class cannot change")
+	cmd.Flags().StringVar(&updateCmd.planName, "plan", "",
+		"The new plan name, within the class of the instance. When the instance references its class by kubernetes name, this is the kubernetes name of the plan")
+	cmd.Flags().StringSliceVarP(&updateCmd.rawParams, "param", "p", nil,
+		"Parameter to set on the instance, format: NAME=VALUE. Cannot be combined with --params-json")
+	cmd.Flags().StringSliceVarP(&updateCmd.rawSecrets, "secret", "s", nil,
+		"Additional parameter, whose value is stored in a secret, format: SECRET[KEY]")
+	cmd.Flags().StringVar(&updateCmd.jsonParams, "params-json", "",
+		"Parameters to merge over the parameters of the instance, provided as a JSON object. Cannot be combined with --param")
+	return cmd
+}
+
+func (c *updateCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.instanceName = args[0]
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+
+	if c.serviceType == "" && c.planName == "" && c.jsonParams == "" && len(c.rawParams) == 0 && len(c.rawSecrets) == 0 {
+		return fmt.Errorf("nothing to update, use svcat touch templated-instance to process the instance again")
+	}
+
+	if c.jsonParams != "" && len(c.rawParams) > 0 {
+		return fmt.Errorf("--params-json cannot be used with --param")
+	}
+
+	c.update = servicecatalogtempltesdk.TemplatedInstanceUpdate{
+		ServiceType: c.serviceType,
+		PlanName:    c.planName,
+	}
+
+	var err error
+	if c.jsonParams != "" {
+		c.update.Params, err = parameters.ParseVariableJSON(c.jsonParams)
+		if err != nil {
+			return fmt.Errorf("invalid --params value (%s)", err)
+		}
+	} else if len(c.rawParams) > 0 {
+		c.update.Params, err = parameters.ParseVariableAssignments(c.rawParams)
+		if err != nil {
+			return fmt.Errorf("invalid --param value (%s)", err)
+		}
+	}
+
+	c.update.Secrets, err = parameters.ParseKeyMaps(c.rawSecrets)
+	if err != nil {
+		return fmt.Errorf("invalid --secret value (%s)", err)
+	}
+
+	return nil
+}
+
+func (c *updateCmd) Run() error {
+	const retries = 3
+	tinst, err := c.App().UpdateTemplatedInstance(c.ns, c.instanceName, c.update, retries)
+	if err != nil {
+		return err
+	}
+
+	svcattoutput.WriteTemplatedInstanceDetails(c.Output, tinst)
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package tree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/output"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type treeCmd struct {
+	*svcattcommand.Context
+	ns       string
+	resource string
+	name     string
+	output   string
+	format   svcattoutput.Format
+}
+
+// NewTreeCmd builds a "svcat tree" command
+func NewTreeCmd(cxt *svcattcommand.Context) *cobra.Command {
+	treeCmd := &treeCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "tree TYPE NAME",
+		Short: "Show the resources managed for a templated instance or binding",
+		Long: `Show the resources managed for a templated instance or binding.
+
+The tree follows the owner references from a templated instance to its service
+instance, or deployment and service, and from each of its templated bindings to
+the service binding, the secret created by service catalog and the projected
+secret. Each resource is shown with its status and last event, and templated
+resources with the templates that contributed to them.
+
+TYPE is templated-instance (tinst) or templated-binding (tbnd).`,
+		Example: `
+  svcat tree templated-instance wordpress-mysql-instance
+  svcat tree tbnd wordpress-mysql-binding -n ci
+  svcat tree tinst wordpress-mysql-instance -o json
+`,
+		PreRunE: command.PreRunE(treeCmd),
+		RunE:    command.RunE(treeCmd),
+	}
+	cmd.Flags().StringVarP(
+		&treeCmd.ns,
+		"namespace",
+		"n",
+		"",
+		"The namespace of the resource",
+	)
+	svcattcommand.AddOutputFlag(cmd.Flags(), &treeCmd.output)
+	return cmd
+}
+
+func (c *treeCmd) Validate(args []string) error {
+	if len(args) == 1 && strings.Contains(args[0], "/") {
+		args = strings.SplitN(args[0], "/", 2)
+	}
+	if len(args) != 2 || args[1] == "" {
+		return fmt.Errorf("a resource type and name are required, e.g. templated-instance NAME")
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "templated-instance", "templated-instances", "templatedinstance", "templatedinstances", "tinst":
+		c.resource = "templated-instance"
+	case "templated-binding", "templated-bindings", "templatedbinding", "templatedbindings", "tbnd":
+		c.resource = "templated-binding"
+	default:
+		return fmt.Errorf("invalid resource type %q, allowed types are: templated-instance, templated-binding", args[0])
+	}
+	c.name = args[1]
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+
+	var err error
+	c.format, err = svcattoutput.ParseFormat(c.output)
+	return err
+}
+
+func (c *treeCmd) Run() error {
+	var root *svcatt.TreeNode
+	var err error
+	if c.resource == "templated-instance" {
+		root, err = c.App().TemplatedInstanceTree(c.ns, c.name)
+	} else {
+		root, err = c.App().TemplatedBindingTree(c.ns, c.name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return svcattoutput.WriteTree(c.Output, c.format, root)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package wait
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/service-catalog-templates/cmd/svcatt/command"
+	"github.com/Azure/service-catalog-templates/pkg/svcatt"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type waitCmd struct {
+	*svcattcommand.Context
+
+	ns           string
+	kind         string
+	name         string
+	rawCondition string
+	condition    svcatt.WaitCondition
+	timeout      time.Duration
+}
+
+// NewWaitCmd builds a "svcat wait" command
+func NewWaitCmd(cxt *svcattcommand.Context) *cobra.Command {
+	waitCmd := &waitCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "wait TYPE/NAME --for=CONDITION",
+		Short: "Wait until a templated instance or binding is ready or deleted",
+		Long: `Wait until a templated instance or binding is ready or deleted.
+
+A templated instance is ready when its service instance is provisioned. A
+templated binding is ready when its service binding is bound and its secret is
+projected. A resource is deleted when it, and the resources that it manages,
+are removed.`,
+		Example: `
+  svcat wait templated-instance/mysql-instance --for=ready
+  svcat wait templated-binding/mysql-binding --for=ready --timeout=10m
+  svcat wait tinst/mysql-instance --for=deleted
+`,
+		PreRunE: command.PreRunE(waitCmd),
+		RunE:    command.RunE(waitCmd),
+	}
+	cmd.Flags().StringVarP(&waitCmd.ns, "namespace", "n", "",
+		"The namespace of the resource")
+	cmd.Flags().StringVar(&waitCmd.rawCondition, "for", string(svcatt.WaitForReady),
+		"The condition to wait for: ready or deleted")
+	cmd.Flags().DurationVar(&waitCmd.timeout, "timeout", svcattcommand.DefaultWaitTimeout,
+		"How long to wait before giving up, e.g. 30s or 10m")
+	return cmd
+}
+
+func (c *waitCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a resource is required, e.g. templated-instance/NAME")
+	}
+
+	parts := strings.SplitN(args[0], "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return fmt.Errorf("invalid resource %q, expected TYPE/NAME", args[0])
+	}
+	c.name = parts[1]
+
+	switch strings.ToLower(parts[0]) {
+	case "templated-instance", "templated-instances", "templatedinstance", "templatedinstances", "tinst":
+		c.kind = "templated-instance"
+	case "templated-binding", "templated-bindings", "templatedbinding", "templatedbindings", "tbnd":
+		c.kind = "templated-binding"
+	default:
+		return fmt.Errorf("invalid resource type %q, allowed types are: templated-instance, templated-binding", parts[0])
+	}
+
+	if c.ns == "" {
+		c.ns = c.App().CurrentNamespace
+	}
+
+	var err error
+	c.condition, err = svcatt.ParseWaitCondition(c.rawCondition)
+	return err
+}
+
+func (c *waitCmd) Run() error {
+	if c.kind == "templated-binding" {
+		return c.WaitForTemplatedBinding(c.ns, c.name, c.condition, c.timeout)
+	}
+	return c.WaitForTemplatedInstance(c.ns, c.name, c.condition, c.timeout)
+}
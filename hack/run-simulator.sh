@@ -0,0 +1,16 @@
+#!/usr/bin/env bash
+
+# Runs the Templates controller against the service catalog simulator instead
+# of a real broker. Service catalog must be installed, its controller manager is
+# scaled down so that the simulator can take over.
+
+set -xeuo pipefail
+
+kubectl scale deployment --namespace svc-cat catalog-catalog-controller-manager --replicas=0
+
+helm upgrade --install svcatt-crd charts/svcatt-crd
+
+go run ./cmd/svcat-simulator/*.go --logtostderr=1 -v=4 &
+trap "kill $!" EXIT
+
+go run ./cmd/service-catalog-templates/*.go --logtostderr=1 -v=10 --leader-elect=false
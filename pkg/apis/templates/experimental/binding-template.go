@@ -9,6 +9,8 @@ import (
 )
 
 type BindingTemplateInterface interface {
+	runtime.Object
+
 	GetName() string
 	GetScope() TemplateScope
 	GetScopeName() string
@@ -16,6 +18,9 @@ type BindingTemplateInterface interface {
 	GetParameters() *runtime.RawExtension
 	GetParametersFrom() []svcat.ParametersFromSource
 	GetSecretKeys() map[string]string
+
+	// GetBindingTemplateSpec returns the spec of the template, which can be modified in place.
+	GetBindingTemplateSpec() *BindingTemplateSpec
 }
 
 func (t *BindingTemplate) GetName() string {
@@ -46,6 +51,10 @@ func (t *BindingTemplate) GetSecretKeys() map[string]string {
 	return t.Spec.SecretKeys
 }
 
+func (t *BindingTemplate) GetBindingTemplateSpec() *BindingTemplateSpec {
+	return &t.Spec
+}
+
 func (t *ClusterBindingTemplate) GetName() string {
 	return t.Name
 }
@@ -74,6 +83,10 @@ func (t *ClusterBindingTemplate) GetSecretKeys() map[string]string {
 	return t.Spec.SecretKeys
 }
 
+func (t *ClusterBindingTemplate) GetBindingTemplateSpec() *BindingTemplateSpec {
+	return (*BindingTemplateSpec)(&t.Spec)
+}
+
 func (t *BrokerBindingTemplate) GetName() string {
 	return t.Name
 }
@@ -101,3 +114,7 @@ func (t *BrokerBindingTemplate) GetParametersFrom() []svcat.ParametersFromSource
 func (t *BrokerBindingTemplate) GetSecretKeys() map[string]string {
 	return t.Spec.SecretKeys
 }
+
+func (t *BrokerBindingTemplate) GetBindingTemplateSpec() *BindingTemplateSpec {
+	return &t.Spec.BindingTemplateSpec
+}
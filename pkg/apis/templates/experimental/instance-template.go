@@ -17,6 +17,8 @@ const (
 )
 
 type InstanceTemplateInterface interface {
+	runtime.Object
+
 	GetName() string
 	GetScope() TemplateScope
 	GetScopeName() string
@@ -25,6 +27,11 @@ type InstanceTemplateInterface interface {
 	SetPlanReference(reference svcat.PlanReference)
 	GetParameters() *runtime.RawExtension
 	GetParametersFrom() []svcat.ParametersFromSource
+	GetProvider() Provider
+	GetContainer() *ContainerProvider
+
+	// GetInstanceTemplateSpec returns the spec of the template, which can be modified in place.
+	GetInstanceTemplateSpec() *InstanceTemplateSpec
 }
 
 func (t *InstanceTemplate) GetName() string {
@@ -59,6 +66,18 @@ func (t *InstanceTemplate) GetParametersFrom() []svcat.ParametersFromSource {
 	return t.Spec.ParametersFrom
 }
 
+func (t *InstanceTemplate) GetProvider() Provider {
+	return t.Spec.Provider
+}
+
+func (t *InstanceTemplate) GetContainer() *ContainerProvider {
+	return t.Spec.Container
+}
+
+func (t *InstanceTemplate) GetInstanceTemplateSpec() *InstanceTemplateSpec {
+	return &t.Spec
+}
+
 func (t *ClusterInstanceTemplate) GetName() string {
 	return t.Name
 }
@@ -91,6 +110,18 @@ func (t *ClusterInstanceTemplate) GetParametersFrom() []svcat.ParametersFromSour
 	return t.Spec.ParametersFrom
 }
 
+func (t *ClusterInstanceTemplate) GetProvider() Provider {
+	return t.Spec.Provider
+}
+
+func (t *ClusterInstanceTemplate) GetContainer() *ContainerProvider {
+	return t.Spec.Container
+}
+
+func (t *ClusterInstanceTemplate) GetInstanceTemplateSpec() *InstanceTemplateSpec {
+	return (*InstanceTemplateSpec)(&t.Spec)
+}
+
 func (t *BrokerInstanceTemplate) GetName() string {
 	return t.Name
 }
@@ -122,3 +153,15 @@ func (t *BrokerInstanceTemplate) GetParameters() *runtime.RawExtension {
 func (t *BrokerInstanceTemplate) GetParametersFrom() []svcat.ParametersFromSource {
 	return t.Spec.ParametersFrom
 }
+
+func (t *BrokerInstanceTemplate) GetProvider() Provider {
+	return t.Spec.Provider
+}
+
+func (t *BrokerInstanceTemplate) GetContainer() *ContainerProvider {
+	return t.Spec.Container
+}
+
+func (t *BrokerInstanceTemplate) GetInstanceTemplateSpec() *InstanceTemplateSpec {
+	return &t.Spec.InstanceTemplateSpec
+}
@@ -38,6 +38,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TemplatedInstanceList{},
 		&BindingTemplate{},
 		&BindingTemplateList{},
+		&ClusterBindingTemplate{},
+		&ClusterBindingTemplateList{},
+		&BrokerBindingTemplate{},
+		&BrokerBindingTemplateList{},
 		&InstanceTemplate{},
 		&InstanceTemplateList{},
 		&ClusterInstanceTemplate{},
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -15,6 +16,22 @@ import (
 
 const (
 	FieldServiceTypeName = "serviceType"
+
+	// LabelTemplatedBinding is set to the name of the templated binding on the
+	// resources that the Templates controller manages for the binding.
+	LabelTemplatedBinding = "templates.servicecatalog.k8s.io/templated-binding"
+
+	// LabelTemplatedInstance is set to the name of the templated instance on the
+	// resources that the Templates controller manages for the instance.
+	LabelTemplatedInstance = "templates.servicecatalog.k8s.io/templated-instance"
+
+	// LabelProvider can be set on a namespace to select the provider of the
+	// templated instances created in the namespace, e.g. Container for a dev namespace.
+	LabelProvider = "templates.servicecatalog.k8s.io/provider"
+
+	// LabelApplySet is set by svcatt apply to the name of the apply set on the templated
+	// resources that it creates, so that they can be pruned once removed from the manifests.
+	LabelApplySet = "templates.servicecatalog.k8s.io/apply-set"
 )
 
 var (
@@ -111,6 +128,14 @@ type InstanceTemplateSpec struct {
 
 	// +optional
 	ParametersFrom []svcat.ParametersFromSource `json:"parametersFrom,omitempty"`
+
+	// Provider selects what provides the instances, defaults to ServiceCatalog.
+	// +optional
+	Provider Provider `json:"provider,omitempty"`
+
+	// Container is used to provide the instances with the Container provider.
+	// +optional
+	Container *ContainerProvider `json:"container,omitempty"`
 }
 
 // InstanceTemplateStatus is the status for a InstanceTemplate resource
@@ -164,6 +189,20 @@ type TemplatedInstanceSpec struct {
 
 	// +optional
 	UpdateRequests int64 `json:"updateRequests"`
+
+	// DriftPolicy determines what happens when the ServiceInstance no
+	// longer matches the spec rendered from this resource.
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// Provider is what provides the instance. It is resolved from the namespace
+	// label or the templates when not specified.
+	// +optional
+	Provider Provider `json:"provider,omitempty"`
+
+	// Container is resolved from the templates when the Container provider is used.
+	// +optional
+	Container *ContainerProvider `json:"container,omitempty"`
 }
 
 // TemplatedInstanceStatus is the status for a TemplatedInstance resource
@@ -171,6 +210,14 @@ type TemplatedInstanceStatus struct {
 	ResolvedClass svcat.ObjectReference `json:"resolvedClass"`
 	ResolvedPlan  svcat.ObjectReference `json:"resolvedPlan"`
 	// TODO: parameters
+
+	// +optional
+	Conditions []TemplatedCondition `json:"conditions,omitempty"`
+
+	// Drift lists the fields of the ServiceInstance that differ from the
+	// rendered spec and were not corrected.
+	// +optional
+	Drift []FieldDrift `json:"drift,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -321,11 +368,24 @@ type TemplatedBindingSpec struct {
 	// Immutable.
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
+
+	// DriftPolicy determines what happens when the ServiceBinding no
+	// longer matches the spec rendered from this resource.
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // TemplatedBindingStatus is the status for a TemplatedBinding resource
 type TemplatedBindingStatus struct {
 	// TODO: parameters, secretKeys
+
+	// +optional
+	Conditions []TemplatedCondition `json:"conditions,omitempty"`
+
+	// Drift lists the fields of the ServiceBinding that differ from the
+	// rendered spec and were not corrected.
+	// +optional
+	Drift []FieldDrift `json:"drift,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -337,3 +397,102 @@ type TemplatedBindingList struct {
 
 	Items []TemplatedBinding `json:"items"`
 }
+
+// DriftPolicy is how the controller handles a managed resource that differs
+// from the spec rendered from its templated resource.
+type DriftPolicy string
+
+const (
+	// DriftPolicyCorrect pushes the rendered spec back onto the managed resource.
+	// This is the default.
+	DriftPolicyCorrect DriftPolicy = "Correct"
+
+	// DriftPolicyReport records the drift on the templated resource's status
+	// but leaves the managed resource untouched.
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// Provider determines what provides the instances of a service type.
+type Provider string
+
+const (
+	// ProviderServiceCatalog provisions a ServiceInstance with service catalog.
+	// This is the default.
+	ProviderServiceCatalog Provider = "ServiceCatalog"
+
+	// ProviderContainer deploys the service into the namespace from a container
+	// template, which is intended for throwaway instances during development.
+	ProviderContainer Provider = "Container"
+)
+
+// ContainerProvider is the template for the Deployment and Service that provide
+// an instance with the Container provider.
+type ContainerProvider struct {
+	// Template of the pods that run the service.
+	Template corev1.PodTemplateSpec `json:"template"`
+
+	// Ports exposed by the Service in front of the pods.
+	Ports []corev1.ServicePort `json:"ports"`
+
+	// Credentials are written to the secret of each binding, using the same
+	// keys as the broker would. Values may reference $(host), $(port),
+	// $(instance) and $(namespace).
+	// +optional
+	Credentials map[string]string `json:"credentials,omitempty"`
+}
+
+// TemplatedConditionType represents a templated resource condition value.
+type TemplatedConditionType string
+
+const (
+	// TemplatedConditionDrifted is true when the managed resource no longer
+	// matches the spec rendered from the templated resource.
+	TemplatedConditionDrifted TemplatedConditionType = "Drifted"
+
+	// TemplatedConditionReady mirrors the Ready condition of the managed resource.
+	TemplatedConditionReady TemplatedConditionType = "Ready"
+
+	// TemplatedConditionResolved is false when the templates for the templated
+	// resource could not be resolved.
+	TemplatedConditionResolved TemplatedConditionType = "Resolved"
+
+	// TemplatedConditionSynced is false when the templated resource could not
+	// be synchronized with its managed resource.
+	TemplatedConditionSynced TemplatedConditionType = "Synced"
+)
+
+// TemplatedCondition contains condition information about a templated resource.
+type TemplatedCondition struct {
+	// Type of the condition.
+	Type TemplatedConditionType `json:"type"`
+
+	// Status of the condition, one of ('True', 'False', 'Unknown').
+	Status svcat.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	Reason string `json:"reason"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	Message string `json:"message"`
+}
+
+// FieldDrift is a single field of a managed resource whose live value differs
+// from the value rendered from its templated resource.
+type FieldDrift struct {
+	// Field is the path to the field, e.g. spec.parameters.location.
+	Field string `json:"field"`
+
+	// Expected is the JSON encoded rendered value, empty when the field should be unset.
+	// +optional
+	Expected string `json:"expected,omitempty"`
+
+	// Actual is the JSON encoded live value, empty when the field is unset.
+	// +optional
+	Actual string `json:"actual,omitempty"`
+}
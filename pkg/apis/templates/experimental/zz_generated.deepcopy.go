@@ -9,7 +9,8 @@ package experimental
 
 import (
 	v1beta1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -514,6 +515,15 @@ func (in *ClusterInstanceTemplateSpec) DeepCopyInto(out *ClusterInstanceTemplate
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ContainerProvider)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -543,6 +553,51 @@ func (in *ClusterInstanceTemplateStatus) DeepCopy() *ClusterInstanceTemplateStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerProvider) DeepCopyInto(out *ContainerProvider) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]v1.ServicePort, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerProvider.
+func (in *ContainerProvider) DeepCopy() *ContainerProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDrift) DeepCopyInto(out *FieldDrift) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDrift.
+func (in *FieldDrift) DeepCopy() *FieldDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceTemplate) DeepCopyInto(out *InstanceTemplate) {
 	*out = *in
@@ -624,6 +679,15 @@ func (in *InstanceTemplateSpec) DeepCopyInto(out *InstanceTemplateSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ContainerProvider)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -659,7 +723,7 @@ func (in *TemplatedBinding) DeepCopyInto(out *TemplatedBinding) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -757,6 +821,18 @@ func (in *TemplatedBindingSpec) DeepCopy() *TemplatedBindingSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplatedBindingStatus) DeepCopyInto(out *TemplatedBindingStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]TemplatedCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]FieldDrift, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -770,13 +846,30 @@ func (in *TemplatedBindingStatus) DeepCopy() *TemplatedBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatedCondition) DeepCopyInto(out *TemplatedCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatedCondition.
+func (in *TemplatedCondition) DeepCopy() *TemplatedCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatedCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplatedInstance) DeepCopyInto(out *TemplatedInstance) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -839,7 +932,7 @@ func (in *TemplatedInstanceSpec) DeepCopyInto(out *TemplatedInstanceSpec) {
 		if *in == nil {
 			*out = nil
 		} else {
-			*out = new(v1.LabelSelector)
+			*out = new(meta_v1.LabelSelector)
 			(*in).DeepCopyInto(*out)
 		}
 	}
@@ -860,6 +953,15 @@ func (in *TemplatedInstanceSpec) DeepCopyInto(out *TemplatedInstanceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ContainerProvider)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -878,6 +980,18 @@ func (in *TemplatedInstanceStatus) DeepCopyInto(out *TemplatedInstanceStatus) {
 	*out = *in
 	out.ResolvedClass = in.ResolvedClass
 	out.ResolvedPlan = in.ResolvedPlan
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]TemplatedCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]FieldDrift, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,192 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package controller
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/juju/ratelimit"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Config tunes the runtime of the Templates controller. It can be loaded
+// from a YAML config file and overridden with flags.
+type Config struct {
+	// Workers is the number of workers for each queue.
+	Workers WorkersConfig `json:"workers"`
+
+	// ResyncPeriod is how often the informers resync their caches.
+	ResyncPeriod metav1.Duration `json:"resyncPeriod"`
+
+	// CacheSyncTimeout is how long to wait for the informer caches to sync
+	// on startup before giving up. Zero waits indefinitely.
+	CacheSyncTimeout metav1.Duration `json:"cacheSyncTimeout"`
+
+	// RateLimiter configures how quickly failed items are retried.
+	RateLimiter RateLimiterConfig `json:"rateLimiter"`
+
+	// Namespaces limits the controller to the specified namespaces. When
+	// empty, all namespaces are watched. The informers only watch a single
+	// included namespace, with multiple namespaces they watch all namespaces
+	// and the events from the others are dropped when they are dispatched.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ExcludeNamespaces are ignored by the controller.
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+}
+
+// WorkersConfig is the number of workers for each queue.
+type WorkersConfig struct {
+	Instances int `json:"instances"`
+	Bindings  int `json:"bindings"`
+	Secrets   int `json:"secrets"`
+}
+
+// RateLimiterConfig combines a per-item exponential backoff with an overall token bucket.
+type RateLimiterConfig struct {
+	// BaseDelay is the delay before the first retry of an item.
+	BaseDelay metav1.Duration `json:"baseDelay"`
+	// MaxDelay is the maximum delay between retries of an item.
+	MaxDelay metav1.Duration `json:"maxDelay"`
+	// QPS is the overall rate at which items are retried.
+	QPS float64 `json:"qps"`
+	// Burst is the number of retries allowed over the QPS.
+	Burst int64 `json:"burst"`
+}
+
+// NewConfig returns the default controller configuration.
+func NewConfig() *Config {
+	return &Config{
+		Workers: WorkersConfig{
+			Instances: 2,
+			Bindings:  2,
+			Secrets:   2,
+		},
+		ResyncPeriod:     metav1.Duration{Duration: 30 * time.Second},
+		CacheSyncTimeout: metav1.Duration{Duration: 2 * time.Minute},
+		RateLimiter: RateLimiterConfig{
+			BaseDelay: metav1.Duration{Duration: 5 * time.Millisecond},
+			MaxDelay:  metav1.Duration{Duration: 1000 * time.Second},
+			QPS:       10,
+			Burst:     100,
+		},
+	}
+}
+
+// AddFlags binds the configuration to command-line flags.
+func (c *Config) AddFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.Workers.Instances, "instance-workers", c.Workers.Instances, "The number of workers synchronizing templated instances.")
+	fs.IntVar(&c.Workers.Bindings, "binding-workers", c.Workers.Bindings, "The number of workers synchronizing templated bindings.")
+	fs.IntVar(&c.Workers.Secrets, "secret-workers", c.Workers.Secrets, "The number of workers synchronizing secrets.")
+	fs.DurationVar(&c.ResyncPeriod.Duration, "resync-period", c.ResyncPeriod.Duration, "How often the informers resync their caches.")
+	fs.DurationVar(&c.CacheSyncTimeout.Duration, "cache-sync-timeout", c.CacheSyncTimeout.Duration, "How long to wait for the informer caches to sync on startup. Set to 0 to wait indefinitely.")
+	fs.DurationVar(&c.RateLimiter.BaseDelay.Duration, "rate-limiter-base-delay", c.RateLimiter.BaseDelay.Duration, "The delay before the first retry of a failed item.")
+	fs.DurationVar(&c.RateLimiter.MaxDelay.Duration, "rate-limiter-max-delay", c.RateLimiter.MaxDelay.Duration, "The maximum delay between retries of a failed item.")
+	fs.Float64Var(&c.RateLimiter.QPS, "rate-limiter-qps", c.RateLimiter.QPS, "The overall rate at which failed items are retried.")
+	fs.Int64Var(&c.RateLimiter.Burst, "rate-limiter-burst", c.RateLimiter.Burst, "The number of retries allowed over the rate limiter QPS.")
+	fs.Var((*stringList)(&c.Namespaces), "namespaces", "Comma-separated list of namespaces to synchronize. Defaults to all namespaces. "+
+		"The informers only watch a single namespace, with multiple namespaces they cache all namespaces and the others are filtered when events are dispatched.")
+	fs.Var((*stringList)(&c.ExcludeNamespaces), "exclude-namespaces", "Comma-separated list of namespaces to ignore.")
+}
+
+// LoadFile reads a YAML config file over the current configuration.
+func (c *Config) LoadFile(path string) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read the config file %s (%s)", path, err)
+	}
+	if err := yaml.Unmarshal(contents, c); err != nil {
+		return fmt.Errorf("unable to parse the config file %s (%s)", path, err)
+	}
+	return nil
+}
+
+// Validate checks that the configuration is usable.
+func (c *Config) Validate() error {
+	if c.Workers.Instances < 1 || c.Workers.Bindings < 1 || c.Workers.Secrets < 1 {
+		return fmt.Errorf("each queue must have at least one worker")
+	}
+	if c.RateLimiter.QPS <= 0 || c.RateLimiter.Burst < 1 {
+		return fmt.Errorf("the rate limiter qps and burst must be greater than zero")
+	}
+	for _, ns := range c.Namespaces {
+		if sets.NewString(c.ExcludeNamespaces...).Has(ns) {
+			return fmt.Errorf("namespace %s is both included and excluded", ns)
+		}
+	}
+	return nil
+}
+
+// WatchesNamespace determines if resources in a namespace should be synchronized.
+// Cluster-scoped resources are always watched.
+func (c *Config) WatchesNamespace(namespace string) bool {
+	if namespace == "" {
+		return true
+	}
+	if len(c.Namespaces) > 0 && !sets.NewString(c.Namespaces...).Has(namespace) {
+		return false
+	}
+	return !sets.NewString(c.ExcludeNamespaces...).Has(namespace)
+}
+
+// InformerNamespace is the namespace that the informer factories should be
+// limited to. Informers can only be limited to a single namespace, so when
+// multiple namespaces are included, all namespaces are watched and the
+// controller filters the events instead.
+func (c *Config) InformerNamespace() string {
+	if len(c.Namespaces) == 1 {
+		return c.Namespaces[0]
+	}
+	return metav1.NamespaceAll
+}
+
+// TweakListOptions excludes namespaces from the lists and watches of the informer factories.
+// It relies on field selectors on metadata.namespace, which are supported by the core API and
+// by custom resources, but not by every aggregated API server.
+func (c *Config) TweakListOptions(options *metav1.ListOptions) {
+	if len(c.ExcludeNamespaces) == 0 {
+		return
+	}
+
+	terms := make([]string, 0, len(c.ExcludeNamespaces)+1)
+	if options.FieldSelector != "" {
+		terms = append(terms, options.FieldSelector)
+	}
+	for _, ns := range c.ExcludeNamespaces {
+		terms = append(terms, "metadata.namespace!="+fields.EscapeValue(ns))
+	}
+	options.FieldSelector = strings.Join(terms, ",")
+}
+
+func (c *Config) newRateLimiter() workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(c.RateLimiter.BaseDelay.Duration, c.RateLimiter.MaxDelay.Duration),
+		&workqueue.BucketRateLimiter{Bucket: ratelimit.NewBucketWithRate(c.RateLimiter.QPS, c.RateLimiter.Burst)},
+	)
+}
+
+// stringList is a comma-separated list flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
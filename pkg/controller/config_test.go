@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package controller
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConfig_WatchesNamespace(t *testing.T) {
+	c := NewConfig()
+	c.Namespaces = []string{"dev", "test"}
+	c.ExcludeNamespaces = []string{"kube-system"}
+
+	testcases := map[string]bool{
+		"dev":         true,
+		"test":        true,
+		"prod":        false,
+		"kube-system": false,
+		"":            true,
+	}
+	for ns, want := range testcases {
+		if got := c.WatchesNamespace(ns); got != want {
+			t.Errorf("WatchesNamespace(%q) = %v, want %v", ns, got, want)
+		}
+	}
+}
+
+func TestConfig_TweakListOptions(t *testing.T) {
+	c := NewConfig()
+	c.ExcludeNamespaces = []string{"kube-system", "svcatt"}
+
+	opts := metav1.ListOptions{FieldSelector: "metadata.name=foo"}
+	c.TweakListOptions(&opts)
+
+	want := "metadata.name=foo,metadata.namespace!=kube-system,metadata.namespace!=svcatt"
+	if opts.FieldSelector != want {
+		t.Fatalf("expected field selector %q, got %q", want, opts.FieldSelector)
+	}
+}
+
+func TestConfig_LoadFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "controller-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString(`
+workers:
+  instances: 4
+cacheSyncTimeout: 10m
+namespaces: [dev]
+`)
+	f.Close()
+
+	c := NewConfig()
+	if err := c.LoadFile(f.Name()); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Workers.Instances != 4 {
+		t.Errorf("expected 4 instance workers, got %d", c.Workers.Instances)
+	}
+	if c.Workers.Bindings != 2 {
+		t.Errorf("expected the default binding workers to be kept, got %d", c.Workers.Bindings)
+	}
+	if c.CacheSyncTimeout.Duration != 10*time.Minute {
+		t.Errorf("expected a 10m cache sync timeout, got %s", c.CacheSyncTimeout.Duration)
+	}
+	if c.InformerNamespace() != "dev" {
+		t.Errorf("expected the informers to be limited to dev, got %q", c.InformerNamespace())
+	}
+}
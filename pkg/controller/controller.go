@@ -5,13 +5,16 @@ package controller
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/Azure/service-catalog-templates/pkg/kubernetes/core-sdk"
+	"github.com/Azure/service-catalog-templates/pkg/metrics"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-sdk"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
 	"github.com/golang/glog"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -23,8 +26,11 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
 	templatesscheme "github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned/scheme"
 	servicecatalogtemplates "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates"
+	sdkerrors "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
 
 	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 )
@@ -36,6 +42,9 @@ const (
 	SuccessSynced = "Synced"
 	// ErrResourceExists is used as part of the Event 'reason' when a shadow resource fails
 	// to sync due to an unmanaged resource of the same name already existing.
+	//
+	// Deprecated: events are recorded with the reason of the class of the error, see
+	// errors.ReasonUnmanagedResource in pkg/service-catalog-templates-sdk/errors.
 	ErrResourceExists = "ErrResourceExists"
 	// MessageResourceSynced is the message used for an Event fired when a shadow resource
 	// is synced successfully
@@ -46,6 +55,7 @@ const (
 // NOTE: This is the stock CRD implementation from https://github.com/kubernetes/sample-controller
 // all interesting logic should live in ../svcatt/synchronizer.go
 type Controller struct {
+	config       *Config
 	synchronizer *servicecatalogtemplates.Synchronizer
 
 	coreSDK     *coresdk.SDK
@@ -64,10 +74,18 @@ type Controller struct {
 	// recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
 	recorder record.EventRecorder
+
+	// health tracks worker progress for the liveness probe.
+	health workerHealth
+
+	// leading is set while Run is processing work, which only happens on the
+	// elected replica. The informer handlers run on every replica, so anything
+	// they write must check it first.
+	leading int32
 }
 
 // NewController returns a new sample controller
-func NewController(coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk.SDK, svcatSDK *servicecatalogsdk.SDK) *Controller {
+func NewController(config *Config, coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk.SDK, svcatSDK *servicecatalogsdk.SDK) *Controller {
 
 	// Create event broadcaster
 	// Add service-catalog-templates-controller types to the default Kubernetes Scheme so Events can be
@@ -80,13 +98,14 @@ func NewController(coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk.S
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
 
 	c := &Controller{
+		config:       config,
 		coreSDK:      coreSDK,
 		templateSDK:  templateSDK,
 		svcatSDK:     svcatSDK,
 		synchronizer: servicecatalogtemplates.NewSynchronizer(coreSDK, templateSDK, svcatSDK),
-		instanceQ:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Instances"),
-		bindingQ:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Bindings"),
-		secretQ:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Secrets"),
+		instanceQ:    workqueue.NewNamedRateLimitingQueue(config.newRateLimiter(), "Instances"),
+		bindingQ:     workqueue.NewNamedRateLimitingQueue(config.newRateLimiter(), "Bindings"),
+		secretQ:      workqueue.NewNamedRateLimitingQueue(config.newRateLimiter(), "Secrets"),
 		recorder:     recorder,
 	}
 
@@ -107,23 +126,31 @@ func NewController(coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk.S
 		},
 		UpdateFunc: func(old, new interface{}) {
 			c.enqueueResource(new, c.bindingQ)
+			// Re-project the binding's secret, e.g. when the secret keys change
+			c.enqueueBindingSecrets(new)
 		},
 	})
+
+	// The secret informer only caches the secrets labeled by the Templates controller.
+	// Both the secret created by service catalog, and the secret projected from it,
+	// are synchronized from the service catalog secret.
 	coreSDK.Cache().Secrets().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			c.enqueueResource(obj, c.secretQ)
-		},
+		AddFunc: c.handleSecret,
 		UpdateFunc: func(old, new interface{}) {
-			c.enqueueResource(new, c.secretQ)
+			c.handleSecret(new)
 		},
+		DeleteFunc: c.handleSecretDeleted,
 	})
 
 	// Set up an event handler for when managed resources change. This
 	// handler will lookup the owner of the given resource, and if it is
 	// owned by a shadow resource will enqueue that resource for
 	// processing. This way, we don't need to implement custom logic for
-	// handling managed resources. More info on this pattern:
+	// handling managed resources. Deleted managed resources also enqueue their
+	// owner, which recreates them when the drift policy allows. More info on this pattern:
 	// https://github.com/kubernetes/community/blob/8cafef897a22026d42f5e5bb3f104febe7e29830/contributors/devel/controllers.md
+	// Condition transitions of service instances and bindings, such as broker failures,
+	// are also recorded as events on the owning templated resource, by the leader only.
 	svcatSDK.Cache().ServiceInstances().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.handleManagedResource,
 		UpdateFunc: func(old, new interface{}) {
@@ -134,6 +161,7 @@ func NewController(coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk.S
 				// Two different versions of the same instance will always have different RVs.
 				return
 			}
+			c.relayConditionEvents(newInst, instanceConditionEvents(oldInst, newInst))
 			c.handleManagedResource(new)
 		},
 		DeleteFunc: c.handleManagedResource,
@@ -148,6 +176,21 @@ func NewController(coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk.S
 				// Two different versions of the same instance will always have different RVs.
 				return
 			}
+			c.relayConditionEvents(newBnd, bindingConditionEvents(oldBnd, newBnd))
+			c.handleManagedResource(new)
+		},
+		DeleteFunc: c.handleManagedResource,
+	})
+	// Deployments of container provided instances report when the instance is ready
+	coreSDK.WorkloadCache().Deployments().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleManagedResource,
+		UpdateFunc: func(old, new interface{}) {
+			newDeployment := new.(*appsv1.Deployment)
+			oldDeployment := old.(*appsv1.Deployment)
+			if newDeployment.ResourceVersion == oldDeployment.ResourceVersion {
+				// Periodic resync will send update events for all known deployments.
+				return
+			}
 			c.handleManagedResource(new)
 		},
 		DeleteFunc: c.handleManagedResource,
@@ -160,25 +203,34 @@ func NewController(coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk.S
 // as syncing informer caches and starting workers. It will block until stopCh
 // is closed, at which point it will shutdown the workqueue and wait for
 // workers to finish processing their current work items.
-func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
+func (c *Controller) Run(stopCh <-chan struct{}) error {
 	defer util.HandleCrash()
 	defer c.instanceQ.ShutDown()
 	defer c.bindingQ.ShutDown()
 	defer c.secretQ.ShutDown()
 
+	atomic.StoreInt32(&c.leading, 1)
+	defer atomic.StoreInt32(&c.leading, 0)
+
 	// Start the informer factories to begin populating the informer caches
 	glog.Info("Starting Templates controller")
-	for i := 0; i < threadiness; i++ {
+	c.health.start("instance", "binding", "secret")
+	defer c.health.stop()
+	for i := 0; i < c.config.Workers.Instances; i++ {
 		go wait.Until(func() {
-			for c.processNextWorkItem(c.instanceQ, c.synchronizer.SynchronizeInstance) {
+			for c.processNextWorkItem("instance", c.instanceQ, c.synchronizer.SynchronizeInstance) {
 			}
 		}, time.Second, stopCh)
+	}
+	for i := 0; i < c.config.Workers.Bindings; i++ {
 		go wait.Until(func() {
-			for c.processNextWorkItem(c.bindingQ, c.synchronizer.SynchronizeBinding) {
+			for c.processNextWorkItem("binding", c.bindingQ, c.synchronizer.SynchronizeBinding) {
 			}
 		}, time.Second, stopCh)
+	}
+	for i := 0; i < c.config.Workers.Secrets; i++ {
 		go wait.Until(func() {
-			for c.processNextWorkItem(c.secretQ, c.synchronizer.SynchronizeSecret) {
+			for c.processNextWorkItem("secret", c.secretQ, c.synchronizer.SynchronizeSecret) {
 			}
 		}, time.Second, stopCh)
 	}
@@ -190,11 +242,20 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
 	return nil
 }
 
+// queues returns the work queues by the name of their sync handler.
+func (c *Controller) queues() map[string]workqueue.RateLimitingInterface {
+	return map[string]workqueue.RateLimitingInterface{
+		"instance": c.instanceQ,
+		"binding":  c.bindingQ,
+		"secret":   c.secretQ,
+	}
+}
+
 type resourceSynchronizationHandler func(key string) (bool, runtime.Object, error)
 
 // processNextWorkItem will read a single work item off the workqueue and
 // attempt to process it, by calling the syncHandler.
-func (c *Controller) processNextWorkItem(q workqueue.RateLimitingInterface, sync resourceSynchronizationHandler) bool {
+func (c *Controller) processNextWorkItem(handler string, q workqueue.RateLimitingInterface, sync resourceSynchronizationHandler) bool {
 	obj, shutdown := q.Get()
 
 	if shutdown {
@@ -221,13 +282,30 @@ func (c *Controller) processNextWorkItem(q workqueue.RateLimitingInterface, sync
 			// As the item in the workqueue is actually invalid, we call
 			// Forget here else we'd go into a loop of attempting to
 			// process a work item that is invalid.
-			c.instanceQ.Forget(obj)
+			q.Forget(obj)
 			util.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
 			return nil
 		}
 		// Run the sync, passing it the namespace/name string of the
 		// Instance resource to be synced.
-		if err := c.synchronizeResource(key, sync); err != nil {
+		start := time.Now()
+		err := c.synchronizeResource(key, sync)
+		metrics.ObserveSync(handler, start, err)
+		c.health.processed(handler)
+		if err != nil {
+			// Transient errors are retried with a back-off. Other errors are
+			// caused by a misconfiguration, or are retried by service catalog,
+			// and are synchronized again when a resource changes or on resync.
+			if sdkerrors.ShouldRetry(err) {
+				q.AddRateLimited(obj)
+			} else {
+				q.Forget(obj)
+			}
+			// Waiting on another resource is the normal ordering of the resources
+			if sdkerrors.IsWaiting(err) {
+				glog.V(4).Infof("%s '%s' is %s", handler, key, err)
+				return nil
+			}
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
 		// Finally, if no error occurs we Forget this item so it does not
@@ -250,9 +328,9 @@ func (c *Controller) processNextWorkItem(q workqueue.RateLimitingInterface, sync
 func (c *Controller) synchronizeResource(key string, sync resourceSynchronizationHandler) error {
 	ok, obj, err := sync(key)
 	if err != nil {
-		// Append a warning to the resource
-		if obj != nil {
-			c.recorder.Event(obj, corev1.EventTypeWarning, ErrResourceExists, err.Error())
+		// Append a warning to the resource, using the class of the error as the reason
+		if obj != nil && !sdkerrors.IsWaiting(err) {
+			c.recorder.Event(obj, corev1.EventTypeWarning, string(sdkerrors.Classify(err).Reason), err.Error())
 		}
 		return err
 	}
@@ -276,33 +354,120 @@ func (c *Controller) enqueueResource(obj interface{}, q workqueue.RateLimitingIn
 		util.HandleError(err)
 		return
 	}
+	if namespace, _, err := cache.SplitMetaNamespaceKey(key); err == nil && !c.config.WatchesNamespace(namespace) {
+		return
+	}
 	q.AddRateLimited(key)
 }
 
+// handleSecret enqueues the service catalog secret of the templated binding
+// that manages a secret.
+func (c *Controller) handleSecret(obj interface{}) {
+	object, ok := decodeObject(obj)
+	if !ok {
+		return
+	}
+
+	tbndName, ok := object.GetLabels()[templates.LabelTemplatedBinding]
+	if !ok {
+		return
+	}
+	tbnd, err := c.templateSDK.GetBindingFromCache(object.GetNamespace(), tbndName)
+	if err != nil {
+		glog.V(4).Infof("ignoring secret '%s' of missing %s '%s'", object.GetName(), templates.BindingKind, tbndName)
+		return
+	}
+
+	c.enqueueKey(tbnd.Namespace, builder.ShadowSecretName(tbnd.Spec.SecretName), c.secretQ)
+}
+
+// handleSecretDeleted enqueues the templated binding that manages a deleted
+// secret, in addition to its service catalog secret, so that the binding
+// status reflects the missing secret.
+func (c *Controller) handleSecretDeleted(obj interface{}) {
+	c.handleSecret(obj)
+
+	object, ok := decodeObject(obj)
+	if !ok {
+		return
+	}
+	if tbndName, ok := object.GetLabels()[templates.LabelTemplatedBinding]; ok {
+		c.enqueueKey(object.GetNamespace(), tbndName, c.bindingQ)
+	}
+}
+
+// enqueueBindingSecrets enqueues the cached secrets managed for a templated binding.
+func (c *Controller) enqueueBindingSecrets(obj interface{}) {
+	object, ok := decodeObject(obj)
+	if !ok {
+		return
+	}
+
+	secrets, err := c.coreSDK.GetSecretsByTemplatedBinding(object.GetNamespace(), object.GetName())
+	if err != nil {
+		util.HandleError(err)
+		return
+	}
+	for _, secret := range secrets {
+		c.handleSecret(secret)
+	}
+}
+
+// enqueueKey puts a namespace/name key onto the specified work queue.
+func (c *Controller) enqueueKey(namespace, name string, q workqueue.RateLimitingInterface) {
+	if !c.config.WatchesNamespace(namespace) {
+		return
+	}
+	q.AddRateLimited(namespace + "/" + name)
+}
+
+// decodeObject returns the object from an informer event, recovering deleted objects from tombstones.
+func decodeObject(obj interface{}) (metav1.Object, bool) {
+	if object, ok := obj.(metav1.Object); ok {
+		return object, true
+	}
+
+	tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+	if !ok {
+		util.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return nil, false
+	}
+	object, ok := tombstone.Obj.(metav1.Object)
+	if !ok {
+		util.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
+		return nil, false
+	}
+	glog.V(4).Infof("Recovered deleted object '%s' from tombstone", object.GetName())
+	return object, true
+}
+
 // handleManagedResource will take any resource implementing metav1.Object and attempt
 // to find the shadow resource that 'owns' it. It does this by looking at the
 // objects metadata.ownerReferences field for an appropriate OwnerReference.
-// It then enqueues that resource to be processed. If the object does not
-// have an appropriate OwnerReference, it will simply be skipped.
+// It then enqueues the owning shadow resource on the work queue for its kind:
+// service instances are processed by the instance queue, service bindings by
+// the binding queue. If the object does not have an appropriate
+// OwnerReference, it will simply be skipped.
 func (c *Controller) handleManagedResource(obj interface{}) {
-	var object metav1.Object
-	var ok bool
-	if object, ok = obj.(metav1.Object); !ok {
-		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
-		if !ok {
-			util.HandleError(fmt.Errorf("error decoding object, invalid type"))
-			return
-		}
-		object, ok = tombstone.Obj.(metav1.Object)
-		if !ok {
-			util.HandleError(fmt.Errorf("error decoding object tombstone, invalid type"))
-			return
-		}
-		glog.V(4).Infof("Recovered deleted object '%s' from tombstone", object.GetName())
+	object, ok := decodeObject(obj)
+	if !ok {
+		return
+	}
+	if !c.config.WatchesNamespace(object.GetNamespace()) {
+		return
 	}
 	glog.V(4).Infof("Processing object: %s", object.GetName())
-	if ok := c.synchronizer.IsManaged(object); ok {
-		c.enqueueResource(obj, c.instanceQ)
+	owner, ok := c.synchronizer.GetManagingResource(object)
+	if !ok {
 		return
 	}
+
+	switch owner.Kind {
+	case templates.InstanceKind:
+		c.enqueueKey(object.GetNamespace(), owner.Name, c.instanceQ)
+	case templates.BindingKind:
+		c.enqueueKey(object.GetNamespace(), owner.Name, c.bindingQ)
+	default:
+		glog.V(4).Infof("ignoring object '%s' managed by unknown kind %s", object.GetName(), owner.Kind)
+	}
 }
@@ -0,0 +1,278 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package controller
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Azure/service-catalog-templates/pkg/kubernetes/core-sdk"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-sdk"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coreinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned/fake"
+	informers "github.com/Azure/service-catalog-templates/pkg/client/informers/externalversions"
+	servicecatalogtemplates "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatinformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
+)
+
+// newTestController builds a controller whose caches are the indexers of
+// informers that are never started, seeded with the specified objects.
+func newTestController(t *testing.T, objs ...runtime.Object) *Controller {
+	coreFactory := coreinformers.NewSharedInformerFactory(nil, 0)
+	coreSDK := coresdk.New(nil, coreFactory, coreFactory, coreFactory)
+	svcatSDK := servicecatalogsdk.New(nil, svcatinformers.NewSharedInformerFactory(nil, 0))
+	templateSDK := servicecatalogtempltesdk.New(fake.NewSimpleClientset(), informers.NewSharedInformerFactory(nil, 0), svcatSDK)
+
+	secrets := coreSDK.Cache().Secrets().Informer()
+	err := secrets.AddIndexers(cache.Indexers{
+		coresdk.TemplatedBindingIndex: func(obj interface{}) ([]string, error) {
+			secret := obj.(*corev1.Secret)
+			if tbnd, ok := secret.Labels[templates.LabelTemplatedBinding]; ok {
+				return []string{secret.Namespace + "/" + tbnd}, nil
+			}
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	for _, obj := range objs {
+		var indexer cache.Indexer
+		switch obj.(type) {
+		case *corev1.Secret:
+			indexer = secrets.GetIndexer()
+		case *templates.TemplatedInstance:
+			indexer = templateSDK.Cache().TemplatedInstances().Informer().GetIndexer()
+		case *templates.TemplatedBinding:
+			indexer = templateSDK.Cache().TemplatedBindings().Informer().GetIndexer()
+		case *svcat.ServiceBinding:
+			indexer = svcatSDK.Cache().ServiceBindings().Informer().GetIndexer()
+		default:
+			t.Fatalf("unexpected cached object %T", obj)
+		}
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+
+	newQueue := func() workqueue.RateLimitingInterface {
+		return workqueue.NewRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(0, 0))
+	}
+	return &Controller{
+		config:       NewConfig(),
+		coreSDK:      coreSDK,
+		templateSDK:  templateSDK,
+		svcatSDK:     svcatSDK,
+		synchronizer: servicecatalogtemplates.NewSynchronizer(coreSDK, templateSDK, svcatSDK),
+		instanceQ:    newQueue(),
+		bindingQ:     newQueue(),
+		secretQ:      newQueue(),
+	}
+}
+
+// drainQueue returns the sorted keys waiting on a work queue.
+func drainQueue(q workqueue.RateLimitingInterface) []string {
+	var keys []string
+	for q.Len() > 0 {
+		key, _ := q.Get()
+		keys = append(keys, key.(string))
+		q.Done(key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestController_SecretHandlers(t *testing.T) {
+	tbnd := &templates.TemplatedBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mybinding"},
+		Spec:       templates.TemplatedBindingSpec{SecretName: "mysecret"},
+	}
+	secret := func(name, tbndName string) *corev1.Secret {
+		s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		if tbndName != "" {
+			s.Labels = map[string]string{templates.LabelTemplatedBinding: tbndName}
+		}
+		return s
+	}
+	shadowKey := "default/" + builder.ShadowSecretName("mysecret")
+
+	testcases := []struct {
+		name        string
+		handler     func(c *Controller) func(obj interface{})
+		obj         interface{}
+		wantSecrets []string
+		wantBinding []string
+	}{
+		{
+			name:        "labelled secret",
+			handler:     func(c *Controller) func(interface{}) { return c.handleSecret },
+			obj:         secret("mysecret", "mybinding"),
+			wantSecrets: []string{shadowKey},
+		},
+		{
+			name:    "unlabelled secret",
+			handler: func(c *Controller) func(interface{}) { return c.handleSecret },
+			obj:     secret("other", ""),
+		},
+		{
+			name:    "secret of missing binding",
+			handler: func(c *Controller) func(interface{}) { return c.handleSecret },
+			obj:     secret("mysecret", "missing"),
+		},
+		{
+			name:        "deleted secret",
+			handler:     func(c *Controller) func(interface{}) { return c.handleSecretDeleted },
+			obj:         secret("mysecret", "mybinding"),
+			wantSecrets: []string{shadowKey},
+			wantBinding: []string{"default/mybinding"},
+		},
+		{
+			name:        "deleted secret tombstone",
+			handler:     func(c *Controller) func(interface{}) { return c.handleSecretDeleted },
+			obj:         cache.DeletedFinalStateUnknown{Key: "default/mysecret", Obj: secret("mysecret", "mybinding")},
+			wantSecrets: []string{shadowKey},
+			wantBinding: []string{"default/mybinding"},
+		},
+		{
+			name:    "deleted unlabelled secret",
+			handler: func(c *Controller) func(interface{}) { return c.handleSecretDeleted },
+			obj:     secret("other", ""),
+		},
+		{
+			name:        "binding secrets",
+			handler:     func(c *Controller) func(interface{}) { return c.enqueueBindingSecrets },
+			obj:         tbnd,
+			wantSecrets: []string{shadowKey},
+		},
+		{
+			name:    "binding without secrets",
+			handler: func(c *Controller) func(interface{}) { return c.enqueueBindingSecrets },
+			obj: &templates.TemplatedBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "otherbinding"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestController(t, tbnd, secret("mysecret", "mybinding"), secret("other", ""))
+
+			tc.handler(c)(tc.obj)
+
+			if got := drainQueue(c.secretQ); !reflect.DeepEqual(got, tc.wantSecrets) {
+				t.Errorf("expected secrets %v to be enqueued, got %v", tc.wantSecrets, got)
+			}
+			if got := drainQueue(c.bindingQ); !reflect.DeepEqual(got, tc.wantBinding) {
+				t.Errorf("expected bindings %v to be enqueued, got %v", tc.wantBinding, got)
+			}
+			if got := drainQueue(c.instanceQ); len(got) > 0 {
+				t.Errorf("expected no instances to be enqueued, got %v", got)
+			}
+		})
+	}
+}
+
+func TestController_HandleManagedResource(t *testing.T) {
+	tinst := &templates.TemplatedInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb"}}
+	tbnd := &templates.TemplatedBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mybinding"}}
+	svcBnd := &svcat.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mybinding",
+			OwnerReferences: ownedBy(templates.BindingKind, "mybinding")},
+	}
+
+	testcases := []struct {
+		name         string
+		obj          interface{}
+		wantInstance []string
+		wantBinding  []string
+	}{
+		{
+			name: "service instance",
+			obj: &svcat.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb",
+				OwnerReferences: ownedBy(templates.InstanceKind, "mydb")}},
+			wantInstance: []string{"default/mydb"},
+		},
+		{
+			name: "deployment",
+			obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb",
+				OwnerReferences: ownedBy(templates.InstanceKind, "mydb")}},
+			wantInstance: []string{"default/mydb"},
+		},
+		{
+			name:        "service binding",
+			obj:         svcBnd,
+			wantBinding: []string{"default/mybinding"},
+		},
+		{
+			name: "secret of service binding",
+			obj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysecret",
+				OwnerReferences: ownedBy("ServiceBinding", "mybinding")}},
+			wantBinding: []string{"default/mybinding"},
+		},
+		{
+			name: "deleted service instance tombstone",
+			obj: cache.DeletedFinalStateUnknown{Key: "default/mydb", Obj: &svcat.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb",
+					OwnerReferences: ownedBy(templates.InstanceKind, "mydb")}}},
+			wantInstance: []string{"default/mydb"},
+		},
+		{
+			name: "unowned service instance",
+			obj:  &svcat.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb"}},
+		},
+		{
+			name: "orphaned deployment",
+			obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "olddb",
+				OwnerReferences: ownedBy(templates.InstanceKind, "olddb")}},
+		},
+		{
+			name: "secret of orphaned service binding",
+			obj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "oldsecret",
+				OwnerReferences: ownedBy("ServiceBinding", "oldbinding")}},
+		},
+		{
+			name: "unknown owner kind",
+			obj: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb",
+				OwnerReferences: ownedBy("ReplicaSet", "mydb")}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestController(t, tinst, tbnd, svcBnd)
+
+			c.handleManagedResource(tc.obj)
+
+			if got := drainQueue(c.instanceQ); !reflect.DeepEqual(got, tc.wantInstance) {
+				t.Errorf("expected instances %v to be enqueued, got %v", tc.wantInstance, got)
+			}
+			if got := drainQueue(c.bindingQ); !reflect.DeepEqual(got, tc.wantBinding) {
+				t.Errorf("expected bindings %v to be enqueued, got %v", tc.wantBinding, got)
+			}
+			if got := drainQueue(c.secretQ); len(got) > 0 {
+				t.Errorf("expected no secrets to be enqueued, got %v", got)
+			}
+		})
+	}
+}
+
+// ownedBy returns the controller owner reference of a managed resource.
+func ownedBy(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// relayedEvent is a condition transition of a managed resource, which is
+// recorded as an event on the templated resource that owns it.
+type relayedEvent struct {
+	eventType string
+	reason    string
+	message   string
+}
+
+// condition is the part of a service instance or service binding condition that is relayed.
+type condition struct {
+	conditionType string
+	status        svcat.ConditionStatus
+	reason        string
+	message       string
+}
+
+// instanceConditionEvents returns an event for each condition of a service instance
+// whose status or reason changed.
+func instanceConditionEvents(old, new *svcat.ServiceInstance) []relayedEvent {
+	toConditions := func(inst *svcat.ServiceInstance) []condition {
+		conditions := make([]condition, 0, len(inst.Status.Conditions))
+		for _, c := range inst.Status.Conditions {
+			conditions = append(conditions, condition{string(c.Type), c.Status, c.Reason, c.Message})
+		}
+		return conditions
+	}
+	return conditionEvents("ServiceInstance", new.Name, toConditions(old), toConditions(new))
+}
+
+// bindingConditionEvents returns an event for each condition of a service binding
+// whose status or reason changed.
+func bindingConditionEvents(old, new *svcat.ServiceBinding) []relayedEvent {
+	toConditions := func(bnd *svcat.ServiceBinding) []condition {
+		conditions := make([]condition, 0, len(bnd.Status.Conditions))
+		for _, c := range bnd.Status.Conditions {
+			conditions = append(conditions, condition{string(c.Type), c.Status, c.Reason, c.Message})
+		}
+		return conditions
+	}
+	return conditionEvents("ServiceBinding", new.Name, toConditions(old), toConditions(new))
+}
+
+func conditionEvents(kind, name string, old, new []condition) []relayedEvent {
+	var events []relayedEvent
+	for _, c := range new {
+		if c.reason == "" || containsCondition(old, c) {
+			continue
+		}
+
+		eventType := corev1.EventTypeNormal
+		if isFailure(c) {
+			eventType = corev1.EventTypeWarning
+		}
+		events = append(events, relayedEvent{
+			eventType: eventType,
+			reason:    c.reason,
+			message:   fmt.Sprintf("%s %s: %s", kind, name, c.message),
+		})
+	}
+	return events
+}
+
+// containsCondition determines if a condition of the same type had the same status and reason.
+func containsCondition(conditions []condition, c condition) bool {
+	for _, existing := range conditions {
+		if existing.conditionType == c.conditionType {
+			return existing.status == c.status && existing.reason == c.reason
+		}
+	}
+	return false
+}
+
+// isFailure determines if a condition reports an error. Service catalog does not
+// classify its reasons, but the reasons of errors say that they failed or errored.
+func isFailure(c condition) bool {
+	if c.conditionType == string(svcat.ServiceInstanceConditionFailed) && c.status == svcat.ConditionTrue {
+		return true
+	}
+	return c.status != svcat.ConditionTrue && (strings.Contains(c.reason, "Error") || strings.Contains(c.reason, "Failed"))
+}
+
+// relayConditionEvents records the condition transitions of a managed resource
+// on the templated resource that owns it. Every replica sees the transitions,
+// so only the leader records them, otherwise each event is duplicated per replica.
+func (c *Controller) relayConditionEvents(object metav1.Object, events []relayedEvent) {
+	if len(events) == 0 || atomic.LoadInt32(&c.leading) == 0 || !c.config.WatchesNamespace(object.GetNamespace()) {
+		return
+	}
+	owner, ok := c.synchronizer.GetManagingResource(object)
+	if !ok {
+		return
+	}
+
+	var templated runtime.Object
+	var err error
+	switch owner.Kind {
+	case templates.InstanceKind:
+		templated, err = c.templateSDK.GetInstanceFromCache(object.GetNamespace(), owner.Name)
+	case templates.BindingKind:
+		templated, err = c.templateSDK.GetBindingFromCache(object.GetNamespace(), owner.Name)
+	default:
+		return
+	}
+	if err != nil {
+		glog.V(4).Infof("unable to relay events of %s to %s %s (%s)", object.GetName(), owner.Kind, owner.Name, err)
+		return
+	}
+
+	for _, e := range events {
+		c.recorder.Event(templated, e.eventType, e.reason, e.message)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package controller
+
+import (
+	"sync/atomic"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestInstanceConditionEvents(t *testing.T) {
+	inst := func(conditions ...svcat.ServiceInstanceCondition) *svcat.ServiceInstance {
+		return &svcat.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "mydb"},
+			Status:     svcat.ServiceInstanceStatus{Conditions: conditions},
+		}
+	}
+	provisioning := svcat.ServiceInstanceCondition{Type: svcat.ServiceInstanceConditionReady, Status: svcat.ConditionFalse,
+		Reason: "Provisioning", Message: "The instance is being provisioned asynchronously"}
+	failed := svcat.ServiceInstanceCondition{Type: svcat.ServiceInstanceConditionReady, Status: svcat.ConditionFalse,
+		Reason: "ProvisionCallFailed", Message: "Error provisioning ServiceInstance"}
+
+	if events := instanceConditionEvents(inst(provisioning), inst(provisioning)); len(events) != 0 {
+		t.Fatalf("expected no events when the conditions did not change, got %v", events)
+	}
+
+	events := instanceConditionEvents(inst(), inst(provisioning))
+	if len(events) != 1 || events[0].eventType != corev1.EventTypeNormal || events[0].reason != "Provisioning" {
+		t.Fatalf("expected a normal Provisioning event, got %v", events)
+	}
+
+	events = instanceConditionEvents(inst(provisioning), inst(failed))
+	if len(events) != 1 || events[0].eventType != corev1.EventTypeWarning {
+		t.Fatalf("expected a warning for the failed provision, got %v", events)
+	}
+	if events[0].message != "ServiceInstance mydb: Error provisioning ServiceInstance" {
+		t.Fatalf("unexpected message %q", events[0].message)
+	}
+}
+
+func TestRelayConditionEvents_LeaderOnly(t *testing.T) {
+	tinst := &templates.TemplatedInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb"}}
+	inst := &svcat.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mydb",
+		OwnerReferences: ownedBy(templates.InstanceKind, "mydb")}}
+	events := []relayedEvent{{eventType: corev1.EventTypeWarning, reason: "ProvisionCallFailed", message: "ServiceInstance mydb: failed"}}
+
+	c := newTestController(t, tinst)
+	recorder := record.NewFakeRecorder(10)
+	c.recorder = recorder
+
+	c.relayConditionEvents(inst, events)
+	if len(recorder.Events) != 0 {
+		t.Fatalf("expected a replica that is not leading to record no events, got %q", <-recorder.Events)
+	}
+
+	atomic.StoreInt32(&c.leading, 1)
+	c.relayConditionEvents(inst, events)
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected the leader to record 1 event, got %d", len(recorder.Events))
+	}
+	if got := <-recorder.Events; got != "Warning ProvisionCallFailed ServiceInstance mydb: failed" {
+		t.Fatalf("unexpected event %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// workerStallTimeout is how long a queue with pending work may go without an
+// item being processed before the workers are considered unhealthy.
+const workerStallTimeout = 5 * time.Minute
+
+// workerHealth tracks when the workers for each queue last finished an item.
+type workerHealth struct {
+	mu            sync.Mutex
+	running       bool
+	lastProcessed map[string]time.Time
+}
+
+func (h *workerHealth) start(handlers ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.running = true
+	h.lastProcessed = make(map[string]time.Time, len(handlers))
+	for _, handler := range handlers {
+		h.lastProcessed[handler] = time.Now()
+	}
+}
+
+func (h *workerHealth) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.running = false
+}
+
+func (h *workerHealth) processed(handler string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastProcessed[handler] = time.Now()
+}
+
+// Healthy returns an error when the workers for a queue with pending work
+// have stopped processing items. A controller that is not running, e.g. a
+// replica waiting to be elected leader, is always healthy.
+func (c *Controller) Healthy() error {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+
+	if !c.health.running {
+		return nil
+	}
+
+	for handler, q := range c.queues() {
+		idle := time.Since(c.health.lastProcessed[handler])
+		if q.Len() > 0 && idle > workerStallTimeout {
+			return fmt.Errorf("the %s workers have not processed an item in %s with %d items pending", handler, idle, q.Len())
+		}
+	}
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/golang/glog"
 	corefactory "k8s.io/client-go/informers"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	coreclient "k8s.io/client-go/kubernetes"
+	appsinterfaces "k8s.io/client-go/kubernetes/typed/apps/v1"
 	coreinterfaces "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -20,23 +22,48 @@ type SDK struct {
 	Client  coreclient.Interface
 	Factory corefactory.SharedInformerFactory
 
+	// WorkloadFactory caches the workloads managed for templated instances
+	// with the Container provider. It is separate from Factory, which only
+	// caches the managed secrets.
+	WorkloadFactory corefactory.SharedInformerFactory
+
+	// NamespaceFactory caches the namespaces, for the provider selected by their label.
+	NamespaceFactory corefactory.SharedInformerFactory
+
 	informers    coreinformers.Interface
 	secretLister corelisters.SecretLister
 }
 
-func New(client coreclient.Interface, factory corefactory.SharedInformerFactory) *SDK {
+func New(client coreclient.Interface, factory corefactory.SharedInformerFactory, workloadFactory corefactory.SharedInformerFactory,
+	namespaceFactory corefactory.SharedInformerFactory) *SDK {
 	return &SDK{
-		Client:  client,
-		Factory: factory,
+		Client:           client,
+		Factory:          factory,
+		WorkloadFactory:  workloadFactory,
+		NamespaceFactory: namespaceFactory,
 	}
 }
 
 func (sdk *SDK) Init(stopCh <-chan struct{}) error {
 	secretsInformer := sdk.Cache().Secrets().Informer()
+	err := secretsInformer.AddIndexers(cache.Indexers{
+		TemplatedBindingIndex: indexByTemplatedBinding,
+	})
+	if err != nil {
+		return err
+	}
+	deploymentsInformer := sdk.WorkloadCache().Deployments().Informer()
+	servicesInformer := sdk.WorkloadFactory.Core().V1().Services().Informer()
+	namespacesInformer := sdk.NamespaceFactory.Core().V1().Namespaces().Informer()
 	go sdk.Factory.Start(stopCh)
+	go sdk.WorkloadFactory.Start(stopCh)
+	go sdk.NamespaceFactory.Start(stopCh)
 
 	if ok := cache.WaitForCacheSync(stopCh,
-		secretsInformer.HasSynced); !ok {
+		secretsInformer.HasSynced,
+		deploymentsInformer.HasSynced,
+		servicesInformer.HasSynced,
+		namespacesInformer.HasSynced); !ok {
 		return fmt.Errorf("failed to wait for core caches to sync")
 	}
 	glog.Info("Finished synchronizing core caches")
@@ -49,6 +76,11 @@ func (sdk *SDK) Core() coreinterfaces.CoreV1Interface {
 	return sdk.Client.CoreV1()
 }
 
+// Apps is the underlying generated Apps versioned interface.
+func (sdk *SDK) Apps() appsinterfaces.AppsV1Interface {
+	return sdk.Client.AppsV1()
+}
+
 func (sdk *SDK) Cache() coreinformers.Interface {
 	if sdk.informers == nil {
 		sdk.informers = sdk.Factory.Core().V1()
@@ -62,3 +94,8 @@ func (sdk *SDK) SecretCache() corelisters.SecretLister {
 	}
 	return sdk.secretLister
 }
+
+// WorkloadCache is the informer cache of the managed workloads.
+func (sdk *SDK) WorkloadCache() appsinformers.Interface {
+	return sdk.WorkloadFactory.Apps().V1()
+}
@@ -4,9 +4,17 @@
 package coresdk
 
 import (
+	"fmt"
+
 	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
 )
 
+// TemplatedBindingIndex indexes the cached secrets by the templated binding that manages them.
+const TemplatedBindingIndex = "templatedBinding"
+
 // GetSecretFromCache retrieves a Secret by name from the informer cache.
 func (sdk *SDK) GetSecretFromCache(namespace, name string) (*core.Secret, error) {
 	s, err := sdk.SecretCache().Secrets(namespace).Get(name)
@@ -15,3 +23,35 @@ func (sdk *SDK) GetSecretFromCache(namespace, name string) (*core.Secret, error)
 	}
 	return s.DeepCopy(), nil
 }
+
+// GetSecret retrieves a Secret by name from the API, for secrets that may not be in the cache yet.
+func (sdk *SDK) GetSecret(namespace, name string) (*core.Secret, error) {
+	return sdk.Core().Secrets(namespace).Get(name, metav1.GetOptions{})
+}
+
+// GetSecretsByTemplatedBinding retrieves the cached secrets managed for a templated binding.
+func (sdk *SDK) GetSecretsByTemplatedBinding(namespace, name string) ([]*core.Secret, error) {
+	objs, err := sdk.Cache().Secrets().Informer().GetIndexer().ByIndex(TemplatedBindingIndex, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*core.Secret, 0, len(objs))
+	for _, obj := range objs {
+		secrets = append(secrets, obj.(*core.Secret).DeepCopy())
+	}
+	return secrets, nil
+}
+
+func indexByTemplatedBinding(obj interface{}) ([]string, error) {
+	secret, ok := obj.(*core.Secret)
+	if !ok {
+		return nil, fmt.Errorf("expected a secret but got %T", obj)
+	}
+
+	tbnd, ok := secret.Labels[templates.LabelTemplatedBinding]
+	if !ok {
+		return nil, nil
+	}
+	return []string{secret.Namespace + "/" + tbnd}, nil
+}
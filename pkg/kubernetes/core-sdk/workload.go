@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package coresdk
+
+import (
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+// GetDeployment retrieves a Deployment by name from the informer cache, falling back
+// to the API for deployments that are not in the cache yet.
+func (sdk *SDK) GetDeployment(namespace, name string) (*apps.Deployment, error) {
+	d, err := sdk.WorkloadCache().Deployments().Lister().Deployments(namespace).Get(name)
+	if err == nil {
+		return d.DeepCopy(), nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	return sdk.Apps().Deployments(namespace).Get(name, metav1.GetOptions{})
+}
+
+// GetService retrieves a Service by name from the informer cache, falling back
+// to the API for services that are not in the cache yet.
+func (sdk *SDK) GetService(namespace, name string) (*core.Service, error) {
+	svc, err := sdk.WorkloadFactory.Core().V1().Services().Lister().Services(namespace).Get(name)
+	if err == nil {
+		return svc.DeepCopy(), nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	return sdk.Core().Services(namespace).Get(name, metav1.GetOptions{})
+}
+
+// GetNamespaceProvider retrieves the provider selected by the label of a namespace,
+// returning an empty provider when the namespace is not labeled. The namespace is
+// read from the informer cache, falling back to the API when it is not cached yet.
+func (sdk *SDK) GetNamespaceProvider(namespace string) (templates.Provider, error) {
+	ns, err := sdk.NamespaceFactory.Core().V1().Namespaces().Lister().Get(namespace)
+	if apierrors.IsNotFound(err) {
+		ns, err = sdk.Core().Namespaces().Get(namespace, metav1.GetOptions{})
+	}
+	if err != nil {
+		return "", err
+	}
+	return templates.Provider(ns.Labels[templates.LabelProvider]), nil
+}
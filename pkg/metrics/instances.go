@@ -0,0 +1,76 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package metrics
+
+import (
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	templateslisters "github.com/Azure/service-catalog-templates/pkg/client/listers/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+)
+
+var templatedInstancesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "templated_instances"),
+	"Number of templated instances by service type, resolved class and plan, and readiness.",
+	[]string{"service_type", "class", "plan", "ready"},
+	nil,
+)
+
+// RegisterTemplatedInstanceCollector reports the number of templated instances
+// in the cache each time the metrics are scraped.
+func RegisterTemplatedInstanceCollector(lister templateslisters.TemplatedInstanceLister) {
+	prometheus.MustRegister(&templatedInstanceCollector{lister: lister})
+}
+
+type templatedInstanceCollector struct {
+	lister templateslisters.TemplatedInstanceLister
+}
+
+type templatedInstanceKey struct {
+	serviceType, class, plan, ready string
+}
+
+func (c *templatedInstanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- templatedInstancesDesc
+}
+
+func (c *templatedInstanceCollector) Collect(ch chan<- prometheus.Metric) {
+	tinsts, err := c.lister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("unable to list templated instances for metrics: %s", err)
+		return
+	}
+
+	counts := map[templatedInstanceKey]float64{}
+	for _, tinst := range tinsts {
+		counts[newTemplatedInstanceKey(tinst)]++
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(templatedInstancesDesc, prometheus.GaugeValue, count,
+			key.serviceType, key.class, key.plan, key.ready)
+	}
+}
+
+func newTemplatedInstanceKey(tinst *templates.TemplatedInstance) templatedInstanceKey {
+	key := templatedInstanceKey{
+		serviceType: tinst.Spec.ServiceType,
+		class:       tinst.Spec.ClusterServiceClassExternalName,
+		plan:        tinst.Spec.ClusterServicePlanExternalName,
+		ready:       "Unknown",
+	}
+	if key.class == "" {
+		key.class = tinst.Spec.ClusterServiceClassName
+	}
+	if key.plan == "" {
+		key.plan = tinst.Spec.ClusterServicePlanName
+	}
+	if cond := builder.GetCondition(tinst.Status.Conditions, templates.TemplatedConditionReady); cond != nil {
+		key.ready = string(cond.Status)
+	}
+	return key
+}
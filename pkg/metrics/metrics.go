@@ -0,0 +1,123 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package metrics exposes Prometheus metrics for the Templates controller.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	sdkerrors "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+)
+
+const namespace = "svcatt"
+
+const (
+	// ResultSuccess labels a successful template resolution.
+	ResultSuccess = "success"
+	// ResultFailure labels a failed template resolution.
+	ResultFailure = "failure"
+
+	// ScopeNone labels a resolution where no templates applied.
+	ScopeNone = "none"
+	// ScopeBroker labels a resolution where a broker template was the most specific template.
+	ScopeBroker = "broker"
+	// ScopeCluster labels a resolution where a cluster template was the most specific template.
+	ScopeCluster = "cluster"
+	// ScopeNamespace labels a resolution where a namespace template was the most specific template.
+	ScopeNamespace = "namespace"
+)
+
+var (
+	syncDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sync_duration_seconds",
+			Help:      "How long a sync handler takes to synchronize a resource.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"handler"},
+	)
+
+	syncErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sync_errors_total",
+			Help:      "Number of failed synchronizations by sync handler and error reason.",
+		},
+		[]string{"handler", "reason"},
+	)
+
+	resolutions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "template_resolutions_total",
+			Help:      "Number of template resolutions by resource kind, service type, scope of the most specific template and result.",
+		},
+		[]string{"kind", "service_type", "scope", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(syncDuration)
+	prometheus.MustRegister(syncErrors)
+	prometheus.MustRegister(resolutions)
+}
+
+// Handler serves the registered metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveSync records the duration and outcome of a sync handler.
+func ObserveSync(handler string, start time.Time, err error) {
+	syncDuration.WithLabelValues(handler).Observe(time.Since(start).Seconds())
+	if err != nil && !sdkerrors.IsWaiting(err) {
+		syncErrors.WithLabelValues(handler, string(sdkerrors.Classify(err).Reason)).Inc()
+	}
+}
+
+// ObserveInstanceResolution records the outcome of resolving the templates for a templated instance.
+func ObserveInstanceResolution(serviceType string, contributors []templates.InstanceTemplateInterface, err error) {
+	scope := ScopeNone
+	if len(contributors) > 0 {
+		switch contributors[len(contributors)-1].(type) {
+		case *templates.InstanceTemplate:
+			scope = ScopeNamespace
+		case *templates.ClusterInstanceTemplate:
+			scope = ScopeCluster
+		case *templates.BrokerInstanceTemplate:
+			scope = ScopeBroker
+		}
+	}
+	observeResolution(templates.InstanceKind, serviceType, scope, err)
+}
+
+// ObserveBindingResolution records the outcome of resolving the templates for a templated binding.
+func ObserveBindingResolution(serviceType string, contributors []templates.BindingTemplateInterface, err error) {
+	scope := ScopeNone
+	if len(contributors) > 0 {
+		switch contributors[len(contributors)-1].(type) {
+		case *templates.BindingTemplate:
+			scope = ScopeNamespace
+		case *templates.ClusterBindingTemplate:
+			scope = ScopeCluster
+		case *templates.BrokerBindingTemplate:
+			scope = ScopeBroker
+		}
+	}
+	observeResolution(templates.BindingKind, serviceType, scope, err)
+}
+
+func observeResolution(kind, serviceType, scope string, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailure
+	}
+	resolutions.WithLabelValues(kind, serviceType, scope, result).Inc()
+}
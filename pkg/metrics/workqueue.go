@@ -0,0 +1,96 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// The workqueue metrics are partitioned by the name of the queue, e.g. Instances.
+var (
+	queueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "workqueue",
+			Name:      "depth",
+			Help:      "Current depth of the workqueue.",
+		},
+		[]string{"queue"},
+	)
+
+	queueAdds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "workqueue",
+			Name:      "adds_total",
+			Help:      "Number of adds handled by the workqueue.",
+		},
+		[]string{"queue"},
+	)
+
+	queueLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace: namespace,
+			Subsystem: "workqueue",
+			Name:      "queue_latency_microseconds",
+			Help:      "How long an item stays in the workqueue before being requested.",
+		},
+		[]string{"queue"},
+	)
+
+	queueWorkDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace: namespace,
+			Subsystem: "workqueue",
+			Name:      "work_duration_microseconds",
+			Help:      "How long processing an item from the workqueue takes.",
+		},
+		[]string{"queue"},
+	)
+
+	queueRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "workqueue",
+			Name:      "retries_total",
+			Help:      "Number of retries handled by the workqueue.",
+		},
+		[]string{"queue"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(queueDepth)
+	prometheus.MustRegister(queueAdds)
+	prometheus.MustRegister(queueLatency)
+	prometheus.MustRegister(queueWorkDuration)
+	prometheus.MustRegister(queueRetries)
+
+	// Named workqueues pick up the provider when they are created,
+	// so it must be set before the controller builds its queues.
+	workqueue.SetProvider(workqueueMetricsProvider{})
+}
+
+type workqueueMetricsProvider struct{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return queueDepth.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return queueAdds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.SummaryMetric {
+	return queueLatency.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.SummaryMetric {
+	return queueWorkDuration.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return queueRetries.WithLabelValues(name)
+}
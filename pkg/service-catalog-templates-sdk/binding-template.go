@@ -141,3 +141,107 @@ func (sdk *SDK) RetrieveBrokerBindingTemplate(name string) (*templates.BrokerBin
 	}
 	return bbndt, nil
 }
+
+// BuildBindingTemplate builds a binding template at the namespace, cluster or broker scope.
+func BuildBindingTemplate(scope templates.TemplateScope, ns, name, brokerName string,
+	spec templates.BindingTemplateSpec) templates.BindingTemplateInterface {
+
+	switch scope {
+	case templates.ScopeBroker:
+		return &templates.BrokerBindingTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: name},
+			Spec: templates.BrokerBindingTemplateSpec{
+				BindingTemplateSpec: spec,
+				BrokerName:          brokerName,
+			},
+		}
+	case templates.ScopeCluster:
+		return &templates.ClusterBindingTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: name},
+			Spec:       templates.ClusterBindingTemplateSpec(spec),
+		}
+	default:
+		return &templates.BindingTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: name, Namespace: ns},
+			Spec:       spec,
+		}
+	}
+}
+
+// CreateBindingTemplate creates a binding template labeled with its service type.
+func (sdk *SDK) CreateBindingTemplate(t templates.BindingTemplateInterface) (templates.BindingTemplateInterface, error) {
+	if err := sdk.prepareBindingTemplate(t); err != nil {
+		return nil, err
+	}
+
+	name := t.GetName()
+	var err error
+	switch bndt := t.(type) {
+	case *templates.BrokerBindingTemplate:
+		t, err = sdk.Templates().BrokerBindingTemplates().Create(bndt)
+	case *templates.ClusterBindingTemplate:
+		t, err = sdk.Templates().ClusterBindingTemplates().Create(bndt)
+	case *templates.BindingTemplate:
+		t, err = sdk.Templates().BindingTemplates(bndt.Namespace).Create(bndt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to create binding template %q (%s)", name, err)
+	}
+	return t, nil
+}
+
+// UpdateBindingTemplate updates a binding template.
+func (sdk *SDK) UpdateBindingTemplate(t templates.BindingTemplateInterface) (templates.BindingTemplateInterface, error) {
+	if err := sdk.prepareBindingTemplate(t); err != nil {
+		return nil, err
+	}
+
+	name := t.GetName()
+	var err error
+	switch bndt := t.(type) {
+	case *templates.BrokerBindingTemplate:
+		t, err = sdk.Templates().BrokerBindingTemplates().Update(bndt)
+	case *templates.ClusterBindingTemplate:
+		t, err = sdk.Templates().ClusterBindingTemplates().Update(bndt)
+	case *templates.BindingTemplate:
+		t, err = sdk.Templates().BindingTemplates(bndt.Namespace).Update(bndt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to update binding template %q (%s)", name, err)
+	}
+	return t, nil
+}
+
+// DeleteBindingTemplate deletes a binding template by its name.
+func (sdk *SDK) DeleteBindingTemplate(ns string, cluster, broker bool, name string) error {
+	var err error
+	switch {
+	case broker:
+		err = sdk.Templates().BrokerBindingTemplates().Delete(name, &meta.DeleteOptions{})
+	case cluster:
+		err = sdk.Templates().ClusterBindingTemplates().Delete(name, &meta.DeleteOptions{})
+	default:
+		err = sdk.Templates().BindingTemplates(ns).Delete(name, &meta.DeleteOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to delete binding template %q (%s)", name, err)
+	}
+	return nil
+}
+
+func (sdk *SDK) prepareBindingTemplate(t templates.BindingTemplateInterface) error {
+	if t.GetServiceType() == "" {
+		return fmt.Errorf("binding template %q requires a service type", t.GetName())
+	}
+
+	if bbndt, ok := t.(*templates.BrokerBindingTemplate); ok {
+		if bbndt.Spec.BrokerName == "" {
+			return fmt.Errorf("broker binding template %q requires a broker name", t.GetName())
+		}
+		if _, err := sdk.svcatSDK.RetrieveBroker(bbndt.Spec.BrokerName); err != nil {
+			return err
+		}
+	}
+
+	return setServiceTypeLabel(t, t.GetServiceType())
+}
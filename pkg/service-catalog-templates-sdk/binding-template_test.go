@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"testing"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+func TestCreateBindingTemplate_LabelsServiceType(t *testing.T) {
+	sdk, err := NewOffline()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bndt := BuildBindingTemplate(templates.ScopeCluster, "", "mysqldb", "", templates.BindingTemplateSpec{
+		ServiceType: "mysqldb",
+		SecretKeys:  map[string]string{"host": "MYSQL_HOST"},
+	})
+	if _, err := sdk.CreateBindingTemplate(bndt); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := sdk.GetBindingTemplates("", true, false, "mysqldb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].GetSecretKeys()["host"] != "MYSQL_HOST" {
+		t.Fatalf("expected the cluster binding template to be found by its service type, got %v", results)
+	}
+
+	bndt = BuildBindingTemplate(templates.ScopeCluster, "", "unlabeled", "", templates.BindingTemplateSpec{})
+	if _, err := sdk.CreateBindingTemplate(bndt); err == nil {
+		t.Fatal("expected a binding template without a service type to be rejected")
+	}
+}
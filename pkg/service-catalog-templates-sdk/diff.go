@@ -0,0 +1,302 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TemplateImpact is how proposed instance templates would change the resolution of a templated instance.
+type TemplateImpact struct {
+	Namespace     string            `json:"namespace"`
+	Name          string            `json:"name"`
+	CurrentClass  string            `json:"currentClass,omitempty"`
+	CurrentPlan   string            `json:"currentPlan,omitempty"`
+	ProposedClass string            `json:"proposedClass,omitempty"`
+	ProposedPlan  string            `json:"proposedPlan,omitempty"`
+	Parameters    []ParameterChange `json:"parameters"`
+
+	// PlanChangeForbidden is why service catalog would reject the change of plan, when it would.
+	PlanChangeForbidden string `json:"planChangeForbidden,omitempty"`
+
+	// Error is why the instance could not be resolved with the proposed templates.
+	Error string `json:"error,omitempty"`
+}
+
+// ParameterChange is a top-level parameter that the proposed templates would add, remove or change.
+// The values are JSON, and empty when the parameter is not set.
+type ParameterChange struct {
+	Name     string `json:"name"`
+	Current  string `json:"current,omitempty"`
+	Proposed string `json:"proposed,omitempty"`
+}
+
+// Changed determines if the resolution of the instance would change.
+func (i TemplateImpact) Changed() bool {
+	return i.Error != "" || i.PlanChanged() || len(i.Parameters) > 0
+}
+
+// PlanChanged determines if the instance would resolve to another class or plan.
+func (i TemplateImpact) PlanChanged() bool {
+	return i.CurrentClass != i.ProposedClass || i.CurrentPlan != i.ProposedPlan
+}
+
+// DiffInstanceTemplates resolves the templated instances that proposed instance templates
+// apply to, both against the current templates and with the proposed templates replacing
+// the templates of the same name, and reports how their plan and parameters would change.
+// The instances of the service types that a replaced template applied to are included.
+func (sdk *SDK) DiffInstanceTemplates(proposed []templates.InstanceTemplateInterface) ([]TemplateImpact, error) {
+	current, err := sdk.listInstanceTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the current templates with the proposed templates, and find which
+	// instances the templates that change apply to
+	replaced := make(map[string]templates.InstanceTemplateInterface, len(current))
+	for _, t := range current {
+		replaced[instanceTemplateKey(t)] = t
+	}
+	affected := templateScopes{}
+	for _, t := range proposed {
+		affected.add(t)
+		if old, ok := replaced[instanceTemplateKey(t)]; ok {
+			affected.add(old)
+		}
+		replaced[instanceTemplateKey(t)] = t
+	}
+
+	objects := make([]runtime.Object, 0, len(replaced))
+	for _, t := range replaced {
+		obj := t.DeepCopyObject()
+		if accessor, ok := obj.(meta.Object); ok {
+			accessor.SetResourceVersion("")
+		}
+		objects = append(objects, obj)
+	}
+	proposedSDK, err := NewOffline(objects...)
+	if err != nil {
+		return nil, err
+	}
+
+	tinsts, err := sdk.ListTemplatedInstances("", TemplatedListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	impacts := []TemplateImpact{}
+	for i := range tinsts.Items {
+		tinst := &tinsts.Items[i]
+		if !affected.includes(tinst) {
+			continue
+		}
+		impact, err := sdk.diffInstance(tinst, proposedSDK)
+		if err != nil {
+			return nil, err
+		}
+		impacts = append(impacts, *impact)
+	}
+
+	sort.Slice(impacts, func(i, j int) bool {
+		return impacts[i].Namespace+"/"+impacts[i].Name < impacts[j].Namespace+"/"+impacts[j].Name
+	})
+	return impacts, nil
+}
+
+func (sdk *SDK) diffInstance(tinst *templates.TemplatedInstance, proposedSDK *SDK) (*TemplateImpact, error) {
+	impact := &TemplateImpact{
+		Namespace:  tinst.Namespace,
+		Name:       tinst.Name,
+		Parameters: []ParameterChange{},
+	}
+
+	// The controller saves the resolved plan and parameters on the templated instance,
+	// so both resolutions start from the instance without what the current templates
+	// contributed. An instance that does not resolve today has no current plan or parameters.
+	request := tinst
+	var current *templates.TemplatedInstance
+	if unresolved, err := sdk.unresolveInstance(tinst); err == nil {
+		if res, err := sdk.ResolveInstance(unresolved); err == nil {
+			request = unresolved
+			current = res.TemplatedInstance
+			impact.CurrentClass, impact.CurrentPlan = planReferenceNames(current.Spec.PlanReference)
+		}
+	}
+
+	res, err := proposedSDK.ResolveInstance(request)
+	if err != nil {
+		impact.Error = err.Error()
+		return impact, nil
+	}
+	proposed := res.TemplatedInstance
+	impact.ProposedClass, impact.ProposedPlan = planReferenceNames(proposed.Spec.PlanReference)
+
+	var currentParams *runtime.RawExtension
+	if current != nil {
+		currentParams = current.Spec.Parameters
+	}
+	impact.Parameters, err = diffParameters(currentParams, proposed.Spec.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compare the parameters of templated instance %s/%s (%s)", tinst.Namespace, tinst.Name, err)
+	}
+
+	if current != nil && impact.PlanChanged() && builder.WasProvisioned(tinst.Status.Conditions) && !builder.UsesContainerProvider(current) {
+		impact.PlanChangeForbidden = sdk.planChangeForbidden(tinst, impact)
+	}
+	return impact, nil
+}
+
+// planChangeForbidden explains why service catalog would not let a provisioned instance
+// change its plan. It is empty when the change is allowed, or the class is not known.
+func (sdk *SDK) planChangeForbidden(tinst *templates.TemplatedInstance, impact *TemplateImpact) string {
+	if impact.CurrentClass != impact.ProposedClass {
+		return fmt.Sprintf("the class of a provisioned instance cannot be changed from %s to %s",
+			impact.CurrentClass, impact.ProposedClass)
+	}
+	if sdk.svcatSDK == nil {
+		return ""
+	}
+
+	var planUpdatable bool
+	if name := tinst.Status.ResolvedClass.Name; name != "" {
+		class, err := sdk.svcatSDK.ServiceCatalog().ClusterServiceClasses().Get(name, meta.GetOptions{})
+		if err != nil {
+			return ""
+		}
+		planUpdatable = class.Spec.PlanUpdatable
+	} else {
+		class, err := sdk.svcatSDK.RetrieveClassByName(impact.CurrentClass)
+		if err != nil {
+			return ""
+		}
+		planUpdatable = class.Spec.PlanUpdatable
+	}
+
+	if planUpdatable {
+		return ""
+	}
+	return fmt.Sprintf("class %s is not plan updatable, the plan cannot be changed from %s to %s",
+		impact.CurrentClass, impact.CurrentPlan, impact.ProposedPlan)
+}
+
+// diffParameters compares the top-level parameters, like svcatt describe service-type attributes them.
+func diffParameters(current, proposed *runtime.RawExtension) ([]ParameterChange, error) {
+	var currentParams, proposedParams map[string]interface{}
+	if current != nil {
+		if err := json.Unmarshal(current.Raw, &currentParams); err != nil {
+			return nil, err
+		}
+	}
+	if proposed != nil {
+		if err := json.Unmarshal(proposed.Raw, &proposedParams); err != nil {
+			return nil, err
+		}
+	}
+
+	names := map[string]interface{}{}
+	for name := range currentParams {
+		names[name] = nil
+	}
+	for name := range proposedParams {
+		names[name] = nil
+	}
+
+	changes := []ParameterChange{}
+	for _, name := range sortedKeys(names) {
+		currentValue, inCurrent := currentParams[name]
+		proposedValue, inProposed := proposedParams[name]
+		if inCurrent && inProposed && reflect.DeepEqual(currentValue, proposedValue) {
+			continue
+		}
+
+		change := ParameterChange{Name: name}
+		if inCurrent {
+			value, _ := json.Marshal(currentValue)
+			change.Current = string(value)
+		}
+		if inProposed {
+			value, _ := json.Marshal(proposedValue)
+			change.Proposed = string(value)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// listInstanceTemplates lists the instance templates of every scope.
+func (sdk *SDK) listInstanceTemplates() ([]templates.InstanceTemplateInterface, error) {
+	var result []templates.InstanceTemplateInterface
+
+	instts, err := sdk.RetrieveInstanceTemplates("", "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range instts.Items {
+		result = append(result, &instts.Items[i])
+	}
+
+	cinstts, err := sdk.RetrieveClusterInstanceTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for i := range cinstts.Items {
+		result = append(result, &cinstts.Items[i])
+	}
+
+	binstts, err := sdk.RetrieveBrokerInstanceTemplatesByServiceType()
+	if err != nil {
+		return nil, err
+	}
+	for i := range binstts.Items {
+		result = append(result, &binstts.Items[i])
+	}
+
+	return result, nil
+}
+
+func instanceTemplateKey(t templates.InstanceTemplateInterface) string {
+	if t.GetScope() == templates.ScopeNamespace {
+		return fmt.Sprintf("%s/%s/%s", t.GetScope(), t.GetScopeName(), t.GetName())
+	}
+	return fmt.Sprintf("%s/%s", t.GetScope(), t.GetName())
+}
+
+// templateScopes maps the service types of templates to the namespaces that they apply to,
+// where an empty namespace is every namespace.
+type templateScopes map[string]map[string]bool
+
+func (s templateScopes) add(t templates.InstanceTemplateInterface) {
+	ns := ""
+	if t.GetScope() == templates.ScopeNamespace {
+		ns = t.GetScopeName()
+	}
+
+	// Templates are selected by their service type label
+	serviceTypes := []string{t.GetServiceType()}
+	if accessor, ok := t.(meta.Object); ok {
+		serviceTypes = append(serviceTypes, accessor.GetLabels()[templates.FieldServiceTypeName])
+	}
+	for _, serviceType := range serviceTypes {
+		if serviceType == "" {
+			continue
+		}
+		if s[serviceType] == nil {
+			s[serviceType] = map[string]bool{}
+		}
+		s[serviceType][ns] = true
+	}
+}
+
+func (s templateScopes) includes(tinst *templates.TemplatedInstance) bool {
+	namespaces := s[tinst.Spec.ServiceType]
+	return namespaces[""] || namespaces[tinst.Namespace]
+}
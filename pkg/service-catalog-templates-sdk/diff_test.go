@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"reflect"
+	"testing"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+func TestDiffInstanceTemplates(t *testing.T) {
+	// The controller saves the resolution on the templated instance, along with the
+	// parameters that were requested for it
+	resolved := newTestTemplatedInstance("ci", "wordpress-db", "mysqldb", svcat.ConditionTrue, nil)
+	resolved.Spec.PlanReference = svcat.PlanReference{
+		ClusterServiceClassExternalName: "azure-mysql",
+		ClusterServicePlanExternalName:  "basic50",
+	}
+	resolved.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"location":"eastus","sku":"B1","firewall":true,"database":"wordpress"}`)}
+
+	instances := map[string]*templates.TemplatedInstance{
+		"unresolved": newTestTemplatedInstance("ci", "wordpress-db", "mysqldb", svcat.ConditionTrue, nil),
+		"resolved":   resolved,
+	}
+	for instName, tinst := range instances {
+		t.Run(instName, func(t *testing.T) {
+			testDiffInstanceTemplates(t, tinst)
+		})
+	}
+}
+
+func testDiffInstanceTemplates(t *testing.T, tinst *templates.TemplatedInstance) {
+	sdk, err := NewOffline(
+		newTestInstanceTemplate("ci", "mysqldb", "mysqldb", "azure-mysql", "basic50", `{"location":"eastus","sku":"B1","firewall":true}`),
+		tinst,
+		newTestTemplatedInstance("ci", "cache", "redis", svcat.ConditionTrue, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name      string
+		template  *templates.InstanceTemplate
+		plan      string
+		params    []ParameterChange
+		forbidden bool
+	}{
+		{
+			name:     "parameters",
+			template: newTestInstanceTemplate("ci", "mysqldb", "mysqldb", "azure-mysql", "basic50", `{"location":"westus","sku":"B1","ssl":true}`),
+			plan:     "basic50",
+			params: []ParameterChange{
+				{Name: "firewall", Current: "true"},
+				{Name: "location", Current: `"eastus"`, Proposed: `"westus"`},
+				{Name: "ssl", Proposed: "true"},
+			},
+		},
+		{
+			name:     "plan",
+			template: newTestInstanceTemplate("ci", "mysqldb", "mysqldb", "azure-mysql", "standard100", `{"location":"eastus","sku":"B1","firewall":true}`),
+			plan:     "standard100",
+			params:   []ParameterChange{},
+		},
+		{
+			name:      "class",
+			template:  newTestInstanceTemplate("ci", "mysqldb", "mysqldb", "azure-postgresql", "basic50", `{"location":"eastus","sku":"B1","firewall":true}`),
+			plan:      "basic50",
+			params:    []ParameterChange{},
+			forbidden: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			impacts, err := sdk.DiffInstanceTemplates([]templates.InstanceTemplateInterface{tc.template})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(impacts) != 1 || impacts[0].Name != "wordpress-db" {
+				t.Fatalf("expected only the mysqldb instance to be affected, got %#v", impacts)
+			}
+
+			impact := impacts[0]
+			if impact.CurrentPlan != "basic50" || impact.ProposedPlan != tc.plan {
+				t.Fatalf("expected the plan to change from basic50 to %s, got %s to %s", tc.plan, impact.CurrentPlan, impact.ProposedPlan)
+			}
+			if !reflect.DeepEqual(impact.Parameters, tc.params) {
+				t.Fatalf("expected parameter changes %#v, got %#v", tc.params, impact.Parameters)
+			}
+			if (impact.PlanChangeForbidden != "") != tc.forbidden {
+				t.Fatalf("expected the change to be forbidden: %t, got %q", tc.forbidden, impact.PlanChangeForbidden)
+			}
+		})
+	}
+}
@@ -3,19 +3,146 @@
 
 package errors
 
-import "errors"
+import (
+	"fmt"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
 
 const (
 	// ErrorUnmanagedResource means the resource exists, but is not owned by
 	// its corresponding shadow resource.
-	ErrorUnmanagedResource = "resource exists, but is not managed by a templated resource"
+	ErrorUnmanagedResource = "resource %q already exists and is not managed by the Templates controller"
+)
+
+// Reason identifies the class of a synchronization error. It is used as the
+// reason of the events and status conditions recorded for the error.
+type Reason string
+
+const (
+	// ReasonTemplateNotFound means no template applies to the templated resource.
+	ReasonTemplateNotFound Reason = "TemplateNotFound"
+
+	// ReasonAmbiguousBroker means more than one broker template applies to the
+	// templated resource and no more specific template picks between them.
+	ReasonAmbiguousBroker Reason = "AmbiguousBrokerTemplate"
+
+	// ReasonInvalidParameters means the parameters of the templated resource
+	// and its templates could not be merged.
+	ReasonInvalidParameters Reason = "InvalidParameters"
+
+	// ReasonBrokerFailure means the broker failed to provision or bind the
+	// managed resource.
+	ReasonBrokerFailure Reason = "BrokerFailure"
+
+	// ReasonUnmanagedResource means a resource with the same name exists, but
+	// is not managed by the templated resource.
+	ReasonUnmanagedResource Reason = "UnmanagedResource"
+
+	// ReasonTransient means a call to the API server failed, e.g. because of a
+	// network failure or a conflicting update.
+	ReasonTransient Reason = "TransientError"
+
+	// ReasonWaiting means the templated resource depends on a resource that is
+	// not ready yet. It is not a failure, the resource is synchronized again later.
+	ReasonWaiting Reason = "Waiting"
 )
 
-func NewUnmanagedResource() error {
-	return errors.New(ErrorUnmanagedResource)
+// Error is a classified synchronization error.
+type Error struct {
+	// Reason is the class of the error.
+	Reason Reason
+
+	// Message describes the error.
+	Message string
+
+	// Condition is the status condition of the templated resource that reports the error.
+	Condition templates.TemplatedConditionType
+
+	// Retry is true when the synchronization should be retried with a back-off.
+	// Errors caused by a misconfiguration are not retried, they are synchronized
+	// again when a resource changes or on the next resync.
+	Retry bool
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(reason Reason, condition templates.TemplatedConditionType, retry bool, format string, a ...interface{}) error {
+	return &Error{
+		Reason:    reason,
+		Message:   fmt.Sprintf(format, a...),
+		Condition: condition,
+		Retry:     retry,
+	}
+}
+
+// NewTemplateNotFound returns an error for a templated resource without an applicable template.
+func NewTemplateNotFound(format string, a ...interface{}) error {
+	return newError(ReasonTemplateNotFound, templates.TemplatedConditionResolved, false, format, a...)
+}
+
+// NewAmbiguousBroker returns an error for a templated resource that matches multiple broker templates.
+func NewAmbiguousBroker(format string, a ...interface{}) error {
+	return newError(ReasonAmbiguousBroker, templates.TemplatedConditionResolved, false, format, a...)
+}
+
+// NewInvalidParameters returns an error for parameters that could not be merged.
+func NewInvalidParameters(format string, a ...interface{}) error {
+	return newError(ReasonInvalidParameters, templates.TemplatedConditionResolved, false, format, a...)
+}
+
+// NewBrokerFailure returns an error for a managed resource that the broker failed to provision or bind.
+// Service catalog retries the broker operation, so the error is not retried by the Templates controller.
+func NewBrokerFailure(format string, a ...interface{}) error {
+	return newError(ReasonBrokerFailure, templates.TemplatedConditionReady, false, format, a...)
+}
+
+// NewUnmanagedResource returns an error for a named resource that exists, but is not
+// managed by its corresponding shadow resource.
+func NewUnmanagedResource(name string) error {
+	return newError(ReasonUnmanagedResource, templates.TemplatedConditionSynced, false, ErrorUnmanagedResource, name)
+}
+
+// NewTransient returns a retriable error for a failed call to the API server.
+func NewTransient(err error) error {
+	return newError(ReasonTransient, templates.TemplatedConditionSynced, true, "%s", err)
+}
+
+// NewWaiting returns a retriable error for a templated resource that waits on another resource.
+// It is not reported on the status or as a warning event.
+func NewWaiting(format string, a ...interface{}) error {
+	return newError(ReasonWaiting, templates.TemplatedConditionSynced, true, format, a...)
+}
+
+// Classify returns the classified error for any error. Errors that were not
+// created by this package are considered transient.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return NewTransient(err).(*Error)
 }
 
 // IsUnmanagedResource returns true if the specified error was created by NewUnmanagedResource.
 func IsUnmanagedResource(err error) bool {
-	return err.Error() == ErrorUnmanagedResource
+	e, ok := err.(*Error)
+	return ok && e.Reason == ReasonUnmanagedResource
+}
+
+// IsWaiting returns true if the specified error was created by NewWaiting.
+func IsWaiting(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.Reason == ReasonWaiting
+}
+
+// ShouldRetry returns true if the synchronization that failed with the
+// specified error should be retried with a back-off.
+func ShouldRetry(err error) bool {
+	e := Classify(err)
+	return e != nil && e.Retry
 }
@@ -0,0 +1,134 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	svcatv1beta1 "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-sdk"
+)
+
+func newTestTemplatedInstance(ns, name, serviceType string, ready svcat.ConditionStatus, labels map[string]string) *templates.TemplatedInstance {
+	return &templates.TemplatedInstance{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: ns, Labels: labels},
+		Spec:       templates.TemplatedInstanceSpec{ServiceType: serviceType, Provider: templates.ProviderServiceCatalog},
+		Status: templates.TemplatedInstanceStatus{
+			Conditions: []templates.TemplatedCondition{{Type: templates.TemplatedConditionReady, Status: ready}},
+		},
+	}
+}
+
+// newTestInstanceTemplate builds an instance template labeled with its service type,
+// which selects a class and plan by their external names.
+func newTestInstanceTemplate(ns, name, serviceType, class, plan, params string) *templates.InstanceTemplate {
+	t := &templates.InstanceTemplate{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Labels:    map[string]string{templates.FieldServiceTypeName: serviceType},
+		},
+		Spec: templates.InstanceTemplateSpec{
+			ServiceType: serviceType,
+			PlanReference: svcat.PlanReference{
+				ClusterServiceClassExternalName: class,
+				ClusterServicePlanExternalName:  plan,
+			},
+		},
+	}
+	if params != "" {
+		t.Spec.Parameters = &runtime.RawExtension{Raw: []byte(params)}
+	}
+	return t
+}
+
+// withCatalog backs the SDK's service catalog lookups with the specified classes and plans.
+// The service catalog clientset does not have a fake in this tree.
+func withCatalog(sdk *SDK, catalog *fakeServiceCatalog) *SDK {
+	sdk.svcatSDK = servicecatalogsdk.New(catalog, nil)
+	return sdk
+}
+
+// fakeServiceCatalog gets the classes and plans of the catalog by their kubernetes names.
+type fakeServiceCatalog struct {
+	clientset.Interface
+	svcatv1beta1.ServicecatalogV1beta1Interface
+
+	classes map[string]*svcat.ClusterServiceClass
+	plans   map[string]*svcat.ClusterServicePlan
+}
+
+func (f *fakeServiceCatalog) ServicecatalogV1beta1() svcatv1beta1.ServicecatalogV1beta1Interface {
+	return f
+}
+
+func (f *fakeServiceCatalog) ClusterServiceClasses() svcatv1beta1.ClusterServiceClassInterface {
+	return fakeClasses{catalog: f}
+}
+
+func (f *fakeServiceCatalog) ClusterServicePlans() svcatv1beta1.ClusterServicePlanInterface {
+	return fakePlans{catalog: f}
+}
+
+type fakeClasses struct {
+	svcatv1beta1.ClusterServiceClassInterface
+	catalog *fakeServiceCatalog
+}
+
+func (f fakeClasses) Get(name string, opts meta.GetOptions) (*svcat.ClusterServiceClass, error) {
+	class, ok := f.catalog.classes[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: svcat.GroupName, Resource: "clusterserviceclasses"}, name)
+	}
+	return class, nil
+}
+
+type fakePlans struct {
+	svcatv1beta1.ClusterServicePlanInterface
+	catalog *fakeServiceCatalog
+}
+
+func (f fakePlans) Get(name string, opts meta.GetOptions) (*svcat.ClusterServicePlan, error) {
+	plan, ok := f.catalog.plans[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: svcat.GroupName, Resource: "clusterserviceplans"}, name)
+	}
+	return plan, nil
+}
+
+// newTestCatalog has the class azure-mysql with the plans basic50 and standard100,
+// named by their ids, and the plan basic of another class.
+func newTestCatalog() *fakeServiceCatalog {
+	plan := func(id, externalName, classID string) *svcat.ClusterServicePlan {
+		return &svcat.ClusterServicePlan{
+			ObjectMeta: meta.ObjectMeta{Name: id},
+			Spec: svcat.ClusterServicePlanSpec{
+				ExternalName:           externalName,
+				ClusterServiceClassRef: svcat.ClusterObjectReference{Name: classID},
+			},
+		}
+	}
+	return &fakeServiceCatalog{
+		classes: map[string]*svcat.ClusterServiceClass{
+			"997b8372-8dac-40ac-ae65-758b4a5075a5": {
+				ObjectMeta: meta.ObjectMeta{Name: "997b8372-8dac-40ac-ae65-758b4a5075a5"},
+				Spec: svcat.ClusterServiceClassSpec{
+					ExternalName:             "azure-mysql",
+					ClusterServiceBrokerName: "osba",
+				},
+			},
+		},
+		plans: map[string]*svcat.ClusterServicePlan{
+			"427559f1-bf2a-45d3-8844-32374a3e58aa": plan("427559f1-bf2a-45d3-8844-32374a3e58aa", "basic50", "997b8372-8dac-40ac-ae65-758b4a5075a5"),
+			"8a1ad4b4-1b9c-4c4b-9dca-0b7d4d2ee6c4": plan("8a1ad4b4-1b9c-4c4b-9dca-0b7d4d2ee6c4", "standard100", "997b8372-8dac-40ac-ae65-758b4a5075a5"),
+			"b9c3e1f2-5f37-4c5e-9a0c-0c2f5b8a7d11": plan("b9c3e1f2-5f37-4c5e-9a0c-0c2f5b8a7d11", "basic", "4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468"),
+		},
+	}
+}
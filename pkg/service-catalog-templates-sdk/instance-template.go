@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -145,3 +146,176 @@ func (sdk *SDK) RetrieveBrokerInstanceTemplate(name string) (*templates.BrokerIn
 	}
 	return binstt, nil
 }
+
+// BuildInstanceTemplate builds an instance template at the namespace, cluster or broker scope.
+func BuildInstanceTemplate(scope templates.TemplateScope, ns, name, brokerName string,
+	spec templates.InstanceTemplateSpec) templates.InstanceTemplateInterface {
+
+	switch scope {
+	case templates.ScopeBroker:
+		return &templates.BrokerInstanceTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: name},
+			Spec: templates.BrokerInstanceTemplateSpec{
+				InstanceTemplateSpec: spec,
+				BrokerName:           brokerName,
+			},
+		}
+	case templates.ScopeCluster:
+		return &templates.ClusterInstanceTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: name},
+			Spec:       templates.ClusterInstanceTemplateSpec(spec),
+		}
+	default:
+		return &templates.InstanceTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: name, Namespace: ns},
+			Spec:       spec,
+		}
+	}
+}
+
+// CreateInstanceTemplate validates the class and plan of an instance template against
+// the catalog, and creates it labeled with its service type. A broker template without
+// a broker name defaults to the broker of its class.
+func (sdk *SDK) CreateInstanceTemplate(t templates.InstanceTemplateInterface) (templates.InstanceTemplateInterface, error) {
+	if err := sdk.prepareInstanceTemplate(t); err != nil {
+		return nil, err
+	}
+
+	name := t.GetName()
+	var err error
+	switch instt := t.(type) {
+	case *templates.BrokerInstanceTemplate:
+		t, err = sdk.Templates().BrokerInstanceTemplates().Create(instt)
+	case *templates.ClusterInstanceTemplate:
+		t, err = sdk.Templates().ClusterInstanceTemplates().Create(instt)
+	case *templates.InstanceTemplate:
+		t, err = sdk.Templates().InstanceTemplates(instt.Namespace).Create(instt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to create instance template %q (%s)", name, err)
+	}
+	return t, nil
+}
+
+// UpdateInstanceTemplate validates the class and plan of an instance template against
+// the catalog, and updates it.
+func (sdk *SDK) UpdateInstanceTemplate(t templates.InstanceTemplateInterface) (templates.InstanceTemplateInterface, error) {
+	if err := sdk.prepareInstanceTemplate(t); err != nil {
+		return nil, err
+	}
+
+	name := t.GetName()
+	var err error
+	switch instt := t.(type) {
+	case *templates.BrokerInstanceTemplate:
+		t, err = sdk.Templates().BrokerInstanceTemplates().Update(instt)
+	case *templates.ClusterInstanceTemplate:
+		t, err = sdk.Templates().ClusterInstanceTemplates().Update(instt)
+	case *templates.InstanceTemplate:
+		t, err = sdk.Templates().InstanceTemplates(instt.Namespace).Update(instt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to update instance template %q (%s)", name, err)
+	}
+	return t, nil
+}
+
+// DeleteInstanceTemplate deletes an instance template by its name.
+func (sdk *SDK) DeleteInstanceTemplate(ns string, cluster, broker bool, name string) error {
+	var err error
+	switch {
+	case broker:
+		err = sdk.Templates().BrokerInstanceTemplates().Delete(name, &meta.DeleteOptions{})
+	case cluster:
+		err = sdk.Templates().ClusterInstanceTemplates().Delete(name, &meta.DeleteOptions{})
+	default:
+		err = sdk.Templates().InstanceTemplates(ns).Delete(name, &meta.DeleteOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to delete instance template %q (%s)", name, err)
+	}
+	return nil
+}
+
+func (sdk *SDK) prepareInstanceTemplate(t templates.InstanceTemplateInterface) error {
+	if t.GetServiceType() == "" {
+		return fmt.Errorf("instance template %q requires a service type", t.GetName())
+	}
+
+	class, err := sdk.ValidatePlanReference(t.GetPlanReference())
+	if err != nil {
+		return err
+	}
+
+	if binstt, ok := t.(*templates.BrokerInstanceTemplate); ok {
+		switch {
+		case binstt.Spec.BrokerName == "" && class == nil:
+			return fmt.Errorf("broker instance template %q requires a broker name or a class", t.GetName())
+		case binstt.Spec.BrokerName == "":
+			binstt.Spec.BrokerName = class.Spec.ClusterServiceBrokerName
+		case class != nil && class.Spec.ClusterServiceBrokerName != binstt.Spec.BrokerName:
+			return fmt.Errorf("class %q is not offered by broker %q", class.Spec.ExternalName, binstt.Spec.BrokerName)
+		default:
+			if _, err := sdk.svcatSDK.RetrieveBroker(binstt.Spec.BrokerName); err != nil {
+				return err
+			}
+		}
+	}
+
+	return setServiceTypeLabel(t, t.GetServiceType())
+}
+
+// ValidatePlanReference checks that the class and plan referenced by their external
+// names, or by their kubernetes names, are in the catalog, and returns the class when
+// one is referenced. A reference that mixes external names and kubernetes names is invalid.
+func (sdk *SDK) ValidatePlanReference(pr svcat.PlanReference) (*svcat.ClusterServiceClass, error) {
+	byExternalName := pr.ClusterServiceClassExternalName != "" || pr.ClusterServicePlanExternalName != ""
+	byName := pr.ClusterServiceClassName != "" || pr.ClusterServicePlanName != ""
+	if byExternalName && byName {
+		return nil, fmt.Errorf("the class and plan must be referenced either by their external names or by their kubernetes names, not both")
+	}
+	if byName {
+		return sdk.validatePlanReferenceByName(pr.ClusterServiceClassName, pr.ClusterServicePlanName)
+	}
+
+	className := pr.ClusterServiceClassExternalName
+	planName := pr.ClusterServicePlanExternalName
+	if className == "" {
+		if planName != "" {
+			return nil, fmt.Errorf("plan %q requires a class", planName)
+		}
+		return nil, nil
+	}
+
+	class, err := sdk.svcatSDK.RetrieveClassByName(className)
+	if err != nil {
+		return nil, err
+	}
+	if planName != "" {
+		if _, err := sdk.svcatSDK.RetrievePlanByClassAndPlanNames(className, planName); err != nil {
+			return nil, err
+		}
+	}
+	return class, nil
+}
+
+func (sdk *SDK) validatePlanReferenceByName(className, planName string) (*svcat.ClusterServiceClass, error) {
+	if className == "" {
+		return nil, fmt.Errorf("plan %q requires a class", planName)
+	}
+
+	class, err := sdk.svcatSDK.RetrieveClassByID(className)
+	if err != nil {
+		return nil, err
+	}
+	if planName != "" {
+		plan, err := sdk.svcatSDK.RetrievePlanByID(planName)
+		if err != nil {
+			return nil, err
+		}
+		if plan.Spec.ClusterServiceClassRef.Name != className {
+			return nil, fmt.Errorf("plan %q does not belong to class %q", planName, className)
+		}
+	}
+	return class, nil
+}
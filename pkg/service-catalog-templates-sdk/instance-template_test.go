@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"strings"
+	"testing"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestValidatePlanReference(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ref       svcat.PlanReference
+		wantClass string
+		wantErr   string
+	}{
+		{
+			name: "no class",
+		},
+		{
+			name:      "class and plan by name",
+			ref:       svcat.PlanReference{ClusterServiceClassName: "997b8372-8dac-40ac-ae65-758b4a5075a5", ClusterServicePlanName: "427559f1-bf2a-45d3-8844-32374a3e58aa"},
+			wantClass: "azure-mysql",
+		},
+		{
+			name:      "class by name",
+			ref:       svcat.PlanReference{ClusterServiceClassName: "997b8372-8dac-40ac-ae65-758b4a5075a5"},
+			wantClass: "azure-mysql",
+		},
+		{
+			name:    "plan by name without a class",
+			ref:     svcat.PlanReference{ClusterServicePlanName: "427559f1-bf2a-45d3-8844-32374a3e58aa"},
+			wantErr: "requires a class",
+		},
+		{
+			name:    "plan by name of another class",
+			ref:     svcat.PlanReference{ClusterServiceClassName: "997b8372-8dac-40ac-ae65-758b4a5075a5", ClusterServicePlanName: "b9c3e1f2-5f37-4c5e-9a0c-0c2f5b8a7d11"},
+			wantErr: "does not belong to class",
+		},
+		{
+			name:    "missing class by name",
+			ref:     svcat.PlanReference{ClusterServiceClassName: "4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468"},
+			wantErr: "not found",
+		},
+		{
+			name:    "missing plan by name",
+			ref:     svcat.PlanReference{ClusterServiceClassName: "997b8372-8dac-40ac-ae65-758b4a5075a5", ClusterServicePlanName: "basic50"},
+			wantErr: "not found",
+		},
+		{
+			name:    "plan by external name without a class",
+			ref:     svcat.PlanReference{ClusterServicePlanExternalName: "basic50"},
+			wantErr: "requires a class",
+		},
+		{
+			name:    "external class and plan by name",
+			ref:     svcat.PlanReference{ClusterServiceClassExternalName: "azure-mysql", ClusterServicePlanName: "427559f1-bf2a-45d3-8844-32374a3e58aa"},
+			wantErr: "not both",
+		},
+		{
+			name:    "class by name and external plan",
+			ref:     svcat.PlanReference{ClusterServiceClassName: "997b8372-8dac-40ac-ae65-758b4a5075a5", ClusterServicePlanExternalName: "basic50"},
+			wantErr: "not both",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sdk, err := NewOffline()
+			if err != nil {
+				t.Fatal(err)
+			}
+			withCatalog(sdk, newTestCatalog())
+
+			class, err := sdk.ValidatePlanReference(tc.ref)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantClass == "" {
+				if class != nil {
+					t.Fatalf("expected no class, got %s", class.Spec.ExternalName)
+				}
+				return
+			}
+			if class == nil || class.Spec.ExternalName != tc.wantClass {
+				t.Fatalf("expected the class %s, got %v", tc.wantClass, class)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LintSeverity is how serious a problem with a template is.
+type LintSeverity string
+
+const (
+	// LintError is a problem that breaks the resolution of templated resources.
+	LintError LintSeverity = "error"
+
+	// LintWarning is a problem that may resolve templated resources differently than intended.
+	LintWarning LintSeverity = "warning"
+)
+
+// LintProblem is a problem found with a template.
+type LintProblem struct {
+	Severity LintSeverity `json:"severity"`
+	Template string       `json:"template"`
+	Message  string       `json:"message"`
+}
+
+// Catalog lists the brokers, classes and plans that templates refer to.
+type Catalog interface {
+	RetrieveBrokers() ([]svcat.ClusterServiceBroker, error)
+	RetrieveClasses() ([]svcat.ClusterServiceClass, error)
+	RetrievePlans() ([]svcat.ClusterServicePlan, error)
+}
+
+// lintedTemplate is the part of an instance or binding template that is linted.
+type lintedTemplate interface {
+	meta.Object
+	GetScope() templates.TemplateScope
+	GetScopeName() string
+	GetServiceType() string
+}
+
+// LintTemplates checks the instance and binding templates for problems that would only
+// surface when a templated resource is resolved: missing service type labels, and more
+// than one template for a service type in the same scope. When the catalog is set, the
+// brokers, classes and plans that the templates refer to must exist in it. Errors are
+// listed before warnings.
+func (sdk *SDK) LintTemplates(catalog Catalog) ([]LintProblem, error) {
+	instts, err := sdk.listInstanceTemplates()
+	if err != nil {
+		return nil, err
+	}
+	bndts, err := sdk.listBindingTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var linted []lintedTemplate
+	for _, t := range instts {
+		if lt, ok := t.(lintedTemplate); ok {
+			linted = append(linted, lt)
+		}
+	}
+	for _, t := range bndts {
+		if lt, ok := t.(lintedTemplate); ok {
+			linted = append(linted, lt)
+		}
+	}
+
+	problems := []LintProblem{}
+	problems = append(problems, lintServiceTypeLabels(linted)...)
+	problems = append(problems, lintDuplicateTemplates(linted)...)
+	if catalog != nil {
+		catalogProblems, err := lintCatalogReferences(catalog, instts, linted)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, catalogProblems...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Severity != problems[j].Severity {
+			return problems[i].Severity == LintError
+		}
+		return problems[i].Template < problems[j].Template
+	})
+	return problems, nil
+}
+
+// lintServiceTypeLabels finds templates that are never selected, because the resolver
+// selects templates by their service type label.
+func lintServiceTypeLabels(linted []lintedTemplate) []LintProblem {
+	var problems []LintProblem
+	for _, t := range linted {
+		label := t.GetLabels()[templates.FieldServiceTypeName]
+		switch {
+		case label == "":
+			problems = append(problems, LintProblem{
+				Severity: LintError,
+				Template: templateName(t),
+				Message:  fmt.Sprintf("missing the %s label, the template is never used", templates.FieldServiceTypeName),
+			})
+		case t.GetServiceType() != "" && t.GetServiceType() != label:
+			problems = append(problems, LintProblem{
+				Severity: LintWarning,
+				Template: templateName(t),
+				Message: fmt.Sprintf("the %s label %q does not match spec.serviceType %q, the label is used",
+					templates.FieldServiceTypeName, label, t.GetServiceType()),
+			})
+		}
+	}
+	return problems
+}
+
+// lintDuplicateTemplates finds service types with more than one template of a kind in the
+// same namespace, or cluster wide, where the resolver uses whichever is listed first. More
+// than one broker template for a service type is only used when a more specific template exists.
+func lintDuplicateTemplates(linted []lintedTemplate) []LintProblem {
+	groups := map[string][]lintedTemplate{}
+	var keys []string
+	for _, t := range linted {
+		label := t.GetLabels()[templates.FieldServiceTypeName]
+		if label == "" {
+			continue
+		}
+
+		// Broker templates are grouped across brokers, since an instance may resolve to any of them
+		key := fmt.Sprintf("%s/%s", templateKind(t), label)
+		if t.GetScope() == templates.ScopeNamespace {
+			key += "/" + t.GetScopeName()
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+
+	var problems []LintProblem
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+
+		for _, t := range group {
+			var others []string
+			for _, other := range group {
+				if other != t {
+					others = append(others, templateName(other))
+				}
+			}
+			label := t.GetLabels()[templates.FieldServiceTypeName]
+
+			problem := LintProblem{Template: templateName(t)}
+			if t.GetScope() == templates.ScopeBroker {
+				problem.Severity = LintWarning
+				problem.Message = fmt.Sprintf("service type %s also has %s, the broker templates are ambiguous unless a cluster or namespace template exists",
+					label, strings.Join(others, ", "))
+			} else {
+				problem.Severity = LintError
+				problem.Message = fmt.Sprintf("service type %s also has %s, only one of them is used",
+					label, strings.Join(others, ", "))
+			}
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// lintCatalogReferences finds broker templates for brokers that are not installed, and
+// instance templates that refer to a class or plan that does not exist.
+func lintCatalogReferences(catalog Catalog, instts []templates.InstanceTemplateInterface, linted []lintedTemplate) ([]LintProblem, error) {
+	brokers, err := catalog.RetrieveBrokers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list brokers (%s)", err)
+	}
+	classes, err := catalog.RetrieveClasses()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list classes (%s)", err)
+	}
+	plans, err := catalog.RetrievePlans()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list plans (%s)", err)
+	}
+
+	var problems []LintProblem
+	installed := make(map[string]bool, len(brokers))
+	for _, b := range brokers {
+		installed[b.Name] = true
+	}
+	for _, t := range linted {
+		if t.GetScope() == templates.ScopeBroker && !installed[t.GetScopeName()] {
+			problems = append(problems, LintProblem{
+				Severity: LintError,
+				Template: templateName(t),
+				Message:  fmt.Sprintf("broker %s is not installed", t.GetScopeName()),
+			})
+		}
+	}
+
+	for _, t := range instts {
+		lt, ok := t.(lintedTemplate)
+		if !ok || t.GetProvider() == templates.ProviderContainer {
+			continue
+		}
+
+		pr := t.GetPlanReference()
+		className, planName := planReferenceNames(pr)
+		var class *svcat.ClusterServiceClass
+		if className != "" {
+			class = findClass(classes, pr)
+			if class == nil {
+				problems = append(problems, LintProblem{
+					Severity: LintError,
+					Template: templateName(lt),
+					Message:  fmt.Sprintf("class %s does not exist", className),
+				})
+				continue
+			}
+			if t.GetScope() == templates.ScopeBroker && class.Spec.ClusterServiceBrokerName != t.GetScopeName() {
+				problems = append(problems, LintProblem{
+					Severity: LintWarning,
+					Template: templateName(lt),
+					Message: fmt.Sprintf("class %s is offered by broker %s, not %s",
+						className, class.Spec.ClusterServiceBrokerName, t.GetScopeName()),
+				})
+			}
+		}
+
+		if planName != "" && !hasPlan(plans, pr, class) {
+			message := fmt.Sprintf("plan %s does not exist", planName)
+			if class != nil {
+				message = fmt.Sprintf("plan %s does not exist for class %s", planName, className)
+			}
+			problems = append(problems, LintProblem{
+				Severity: LintError,
+				Template: templateName(lt),
+				Message:  message,
+			})
+		}
+	}
+	return problems, nil
+}
+
+func findClass(classes []svcat.ClusterServiceClass, pr svcat.PlanReference) *svcat.ClusterServiceClass {
+	for i, class := range classes {
+		if pr.ClusterServiceClassExternalName != "" && class.Spec.ExternalName == pr.ClusterServiceClassExternalName {
+			return &classes[i]
+		}
+		if pr.ClusterServiceClassExternalName == "" && class.Name == pr.ClusterServiceClassName {
+			return &classes[i]
+		}
+	}
+	return nil
+}
+
+// hasPlan determines if a plan exists, for the class when it is known. Templates may set
+// a plan without a class, for the class set by a less specific template.
+func hasPlan(plans []svcat.ClusterServicePlan, pr svcat.PlanReference, class *svcat.ClusterServiceClass) bool {
+	for _, plan := range plans {
+		if class != nil && plan.Spec.ClusterServiceClassRef.Name != class.Name {
+			continue
+		}
+		if pr.ClusterServicePlanExternalName != "" && plan.Spec.ExternalName == pr.ClusterServicePlanExternalName {
+			return true
+		}
+		if pr.ClusterServicePlanExternalName == "" && plan.Name == pr.ClusterServicePlanName {
+			return true
+		}
+	}
+	return false
+}
+
+// listBindingTemplates lists the binding templates of every scope.
+func (sdk *SDK) listBindingTemplates() ([]templates.BindingTemplateInterface, error) {
+	var result []templates.BindingTemplateInterface
+
+	bndts, err := sdk.RetrieveBindingTemplates("", "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range bndts.Items {
+		result = append(result, &bndts.Items[i])
+	}
+
+	cbndts, err := sdk.RetrieveClusterBindingTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for i := range cbndts.Items {
+		result = append(result, &cbndts.Items[i])
+	}
+
+	bbndts, err := sdk.RetrieveBrokerBindingTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for i := range bbndts.Items {
+		result = append(result, &bbndts.Items[i])
+	}
+
+	return result, nil
+}
+
+func templateKind(t lintedTemplate) string {
+	return strings.Split(fmt.Sprintf("%T", t), ".")[1]
+}
+
+func templateName(t lintedTemplate) string {
+	if t.GetNamespace() != "" {
+		return fmt.Sprintf("%s %s/%s", templateKind(t), t.GetNamespace(), t.GetName())
+	}
+	return fmt.Sprintf("%s %s", templateKind(t), t.GetName())
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"reflect"
+	"testing"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+type fakeCatalog struct {
+	brokers []svcat.ClusterServiceBroker
+	classes []svcat.ClusterServiceClass
+	plans   []svcat.ClusterServicePlan
+}
+
+func (c fakeCatalog) RetrieveBrokers() ([]svcat.ClusterServiceBroker, error) { return c.brokers, nil }
+func (c fakeCatalog) RetrieveClasses() ([]svcat.ClusterServiceClass, error)  { return c.classes, nil }
+func (c fakeCatalog) RetrievePlans() ([]svcat.ClusterServicePlan, error)     { return c.plans, nil }
+
+func TestLintTemplates(t *testing.T) {
+	unlabeled := newTestInstanceTemplate("dev", "mysql", "mysqldb", "azure-mysql", "basic50", "")
+	delete(unlabeled.Labels, templates.FieldServiceTypeName)
+
+	sdk, err := NewOffline(
+		newTestInstanceTemplate("ci", "mysql", "mysqldb", "azure-mysql", "basic50", ""),
+		newTestInstanceTemplate("ci", "mysql-copy", "mysqldb", "azure-mysql", "basic50", ""),
+		unlabeled,
+		newTestInstanceTemplate("prod", "mysql", "mysqldb", "azure-mysql", "premium", ""),
+		&templates.BrokerInstanceTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: "mysql", Labels: map[string]string{templates.FieldServiceTypeName: "mysqldb"}},
+			Spec: templates.BrokerInstanceTemplateSpec{
+				BrokerName:           "aws",
+				InstanceTemplateSpec: templates.InstanceTemplateSpec{ServiceType: "mysqldb"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("offline", func(t *testing.T) {
+		problems, err := sdk.LintTemplates(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []LintProblem{
+			{Severity: LintError, Template: "InstanceTemplate ci/mysql", Message: "service type mysqldb also has InstanceTemplate ci/mysql-copy, only one of them is used"},
+			{Severity: LintError, Template: "InstanceTemplate ci/mysql-copy", Message: "service type mysqldb also has InstanceTemplate ci/mysql, only one of them is used"},
+			{Severity: LintError, Template: "InstanceTemplate dev/mysql", Message: "missing the serviceType label, the template is never used"},
+		}
+		if !reflect.DeepEqual(want, problems) {
+			t.Fatalf("unexpected problems\nwant: %#v\ngot:  %#v", want, problems)
+		}
+	})
+
+	t.Run("catalog", func(t *testing.T) {
+		catalog := fakeCatalog{
+			brokers: []svcat.ClusterServiceBroker{{ObjectMeta: meta.ObjectMeta{Name: "azure"}}},
+			classes: []svcat.ClusterServiceClass{{
+				ObjectMeta: meta.ObjectMeta{Name: "class-1"},
+				Spec: svcat.ClusterServiceClassSpec{
+					ClusterServiceBrokerName: "azure",
+					ExternalName:             "azure-mysql",
+				},
+			}},
+			plans: []svcat.ClusterServicePlan{{
+				ObjectMeta: meta.ObjectMeta{Name: "plan-1"},
+				Spec: svcat.ClusterServicePlanSpec{
+					ClusterServiceClassRef: svcat.ClusterObjectReference{Name: "class-1"},
+					ExternalName:           "basic50",
+				},
+			}},
+		}
+
+		problems, err := sdk.LintTemplates(catalog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]LintProblem{}
+		for _, p := range problems {
+			got[p.Message] = p
+		}
+		for _, message := range []string{
+			"broker aws is not installed",
+			"plan premium does not exist for class azure-mysql",
+		} {
+			if p, ok := got[message]; !ok || p.Severity != LintError {
+				t.Errorf("expected the error %q, got %#v", message, problems)
+			}
+		}
+		if len(problems) != 5 {
+			t.Fatalf("expected 5 problems, got %#v", problems)
+		}
+	})
+}
@@ -0,0 +1,262 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListPageSize is the number of templated resources requested by each List call.
+const ListPageSize = 250
+
+// TemplatedListOptions filters listings of templated resources. Empty fields match everything.
+type TemplatedListOptions struct {
+	// Selector is a label selector, e.g. app=wordpress.
+	Selector string
+
+	// ServiceType matches instances of the service type, and the bindings to them.
+	ServiceType string
+
+	// Status matches the summarized status of the resource: Ready, Pending or Failed.
+	Status string
+}
+
+// ParseStatus validates the value of a status filter, ignoring its case.
+func ParseStatus(value string) (string, error) {
+	for _, status := range []string{builder.StatusReady, builder.StatusPending, builder.StatusFailed} {
+		if strings.EqualFold(value, status) {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid status %q, allowed statuses are: %s, %s, %s",
+		value, builder.StatusReady, builder.StatusPending, builder.StatusFailed)
+}
+
+func (opts TemplatedListOptions) matchesStatus(conditions []templates.TemplatedCondition) bool {
+	return opts.Status == "" || builder.GetStatus(conditions) == opts.Status
+}
+
+func (opts TemplatedListOptions) matchesInstance(tinst *templates.TemplatedInstance) bool {
+	if opts.ServiceType != "" && tinst.Spec.ServiceType != opts.ServiceType {
+		return false
+	}
+	return opts.matchesStatus(tinst.Status.Conditions)
+}
+
+// matchesBinding determines if a binding matches the options, given the service
+// type of its instance.
+func (opts TemplatedListOptions) matchesBinding(tbnd *templates.TemplatedBinding, serviceType string) bool {
+	if opts.ServiceType != "" && serviceType != opts.ServiceType {
+		return false
+	}
+	return opts.matchesStatus(tbnd.Status.Conditions)
+}
+
+// ListTemplatedInstances lists the instances in a namespace, or in all namespaces when
+// the namespace is empty, that match the options. The instances are requested in pages,
+// and the resource version of the list is that of the last page.
+func (sdk *SDK) ListTemplatedInstances(ns string, opts TemplatedListOptions) (*templates.TemplatedInstanceList, error) {
+	result := &templates.TemplatedInstanceList{Items: []templates.TemplatedInstance{}}
+	listOpts := meta.ListOptions{LabelSelector: opts.Selector, Limit: ListPageSize}
+	for {
+		page, err := sdk.Templates().TemplatedInstances(ns).List(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list templated instances in %s (%s)", ns, err)
+		}
+
+		for _, tinst := range page.Items {
+			if opts.matchesInstance(&tinst) {
+				result.Items = append(result.Items, tinst)
+			}
+		}
+
+		if page.Continue == "" {
+			result.ResourceVersion = page.ResourceVersion
+			return result, nil
+		}
+		listOpts.Continue = page.Continue
+	}
+}
+
+// ListTemplatedBindings lists the bindings in a namespace, or in all namespaces when
+// the namespace is empty, that match the options. The bindings are requested in pages,
+// and the resource version of the list is that of the last page.
+func (sdk *SDK) ListTemplatedBindings(ns string, opts TemplatedListOptions) (*templates.TemplatedBindingList, error) {
+	serviceTypes, err := sdk.instanceServiceTypes(ns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &templates.TemplatedBindingList{Items: []templates.TemplatedBinding{}}
+	listOpts := meta.ListOptions{LabelSelector: opts.Selector, Limit: ListPageSize}
+	for {
+		page, err := sdk.Templates().TemplatedBindings(ns).List(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list bindings in %s (%s)", ns, err)
+		}
+
+		for _, tbnd := range page.Items {
+			if opts.matchesBinding(&tbnd, serviceTypes.lookup(sdk, &tbnd)) {
+				result.Items = append(result.Items, tbnd)
+			}
+		}
+
+		if page.Continue == "" {
+			result.ResourceVersion = page.ResourceVersion
+			return result, nil
+		}
+		listOpts.Continue = page.Continue
+	}
+}
+
+// serviceTypes maps namespace/name of the instances to their service type.
+type serviceTypes map[string]string
+
+// instanceServiceTypes maps the instances in a namespace to their service type, since the
+// service type of a binding is the service type of its instance. It is nil when the options
+// do not filter on a service type.
+func (sdk *SDK) instanceServiceTypes(ns string, opts TemplatedListOptions) (serviceTypes, error) {
+	if opts.ServiceType == "" {
+		return nil, nil
+	}
+
+	tinsts, err := sdk.ListTemplatedInstances(ns, TemplatedListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	types := make(serviceTypes, len(tinsts.Items))
+	for _, tinst := range tinsts.Items {
+		types[tinst.Namespace+"/"+tinst.Name] = tinst.Spec.ServiceType
+	}
+	return types, nil
+}
+
+// lookup returns the service type of the instance of a binding, retrieving instances
+// that were created after the map was built.
+func (types serviceTypes) lookup(sdk *SDK, tbnd *templates.TemplatedBinding) string {
+	if types == nil {
+		return ""
+	}
+
+	key := tbnd.Namespace + "/" + tbnd.Spec.TemplatedInstanceRef.Name
+	serviceType, ok := types[key]
+	if !ok {
+		tinst, err := sdk.Templates().TemplatedInstances(tbnd.Namespace).Get(tbnd.Spec.TemplatedInstanceRef.Name, meta.GetOptions{})
+		if err == nil {
+			serviceType = tinst.Spec.ServiceType
+			types[key] = serviceType
+		}
+	}
+	return serviceType
+}
+
+// TemplatedInstanceSummary counts the instances of a service type that are provided
+// the same way, by their status.
+type TemplatedInstanceSummary struct {
+	ServiceType string             `json:"serviceType"`
+	Provider    templates.Provider `json:"provider"`
+	Broker      string             `json:"broker"`
+	Ready       int                `json:"ready"`
+	Pending     int                `json:"pending"`
+	Failed      int                `json:"failed"`
+}
+
+// Total is the number of instances counted by the summary.
+func (s TemplatedInstanceSummary) Total() int {
+	return s.Ready + s.Pending + s.Failed
+}
+
+// SummarizeTemplatedInstances groups the instances in a namespace, or in all namespaces
+// when the namespace is empty, by service type, provider and the broker of their resolved
+// class, and counts them by status. The broker is empty for instances that are not resolved,
+// are provided by a container, or when the service catalog is not available.
+func (sdk *SDK) SummarizeTemplatedInstances(ns string) ([]TemplatedInstanceSummary, error) {
+	tinsts, err := sdk.ListTemplatedInstances(ns, TemplatedListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	brokers := map[string]string{}
+	groups := map[TemplatedInstanceSummary]*TemplatedInstanceSummary{}
+	for i := range tinsts.Items {
+		tinst := &tinsts.Items[i]
+		key := TemplatedInstanceSummary{
+			ServiceType: tinst.Spec.ServiceType,
+			Provider:    tinst.Spec.Provider,
+			Broker:      sdk.resolvedBroker(tinst, brokers),
+		}
+		summary, ok := groups[key]
+		if !ok {
+			summary = &TemplatedInstanceSummary{ServiceType: key.ServiceType, Provider: key.Provider, Broker: key.Broker}
+			groups[key] = summary
+		}
+
+		switch builder.GetStatus(tinst.Status.Conditions) {
+		case builder.StatusReady:
+			summary.Ready++
+		case builder.StatusFailed:
+			summary.Failed++
+		default:
+			summary.Pending++
+		}
+	}
+
+	results := make([]TemplatedInstanceSummary, 0, len(groups))
+	for _, summary := range groups {
+		results = append(results, *summary)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].ServiceType != results[j].ServiceType {
+			return results[i].ServiceType < results[j].ServiceType
+		}
+		if results[i].Provider != results[j].Provider {
+			return results[i].Provider < results[j].Provider
+		}
+		return results[i].Broker < results[j].Broker
+	})
+	return results, nil
+}
+
+// resolvedBroker looks up the broker of the class that an instance resolved to,
+// remembering the broker of each class that was looked up.
+func (sdk *SDK) resolvedBroker(tinst *templates.TemplatedInstance, brokers map[string]string) string {
+	if sdk.svcatSDK == nil || builder.UsesContainerProvider(tinst) {
+		return ""
+	}
+
+	className := tinst.Status.ResolvedClass.Name
+	if className == "" {
+		className = tinst.Spec.ClusterServiceClassName
+	}
+	externalName := tinst.Spec.ClusterServiceClassExternalName
+	if className == "" && externalName == "" {
+		return ""
+	}
+
+	key := className + "/" + externalName
+	if broker, ok := brokers[key]; ok {
+		return broker
+	}
+
+	var broker string
+	if className != "" {
+		class, err := sdk.svcatSDK.ServiceCatalog().ClusterServiceClasses().Get(className, meta.GetOptions{})
+		if err == nil {
+			broker = class.Spec.ClusterServiceBrokerName
+		}
+	} else {
+		class, err := sdk.svcatSDK.RetrieveClassByName(externalName)
+		if err == nil {
+			broker = class.Spec.ClusterServiceBrokerName
+		}
+	}
+	brokers[key] = broker
+	return broker
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"testing"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+)
+
+func TestListTemplatedInstances_Filters(t *testing.T) {
+	sdk, err := NewOffline(
+		newTestTemplatedInstance("teamA", "wordpress-db", "mysqldb", svcat.ConditionTrue, map[string]string{"app": "wordpress"}),
+		newTestTemplatedInstance("teamA", "cache", "redis", svcat.ConditionUnknown, nil),
+		newTestTemplatedInstance("teamB", "ghost-db", "mysqldb", svcat.ConditionUnknown, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name  string
+		ns    string
+		opts  TemplatedListOptions
+		count int
+	}{
+		{"all namespaces", "", TemplatedListOptions{}, 3},
+		{"namespace", "teamA", TemplatedListOptions{}, 2},
+		{"service type", "", TemplatedListOptions{ServiceType: "mysqldb"}, 2},
+		{"status", "", TemplatedListOptions{Status: builder.StatusPending}, 2},
+		{"type and status", "", TemplatedListOptions{ServiceType: "mysqldb", Status: builder.StatusReady}, 1},
+		{"selector", "", TemplatedListOptions{Selector: "app=wordpress"}, 1},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tinsts, err := sdk.ListTemplatedInstances(tc.ns, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tinsts.Items) != tc.count {
+				t.Fatalf("expected %d instances, got %d", tc.count, len(tinsts.Items))
+			}
+		})
+	}
+}
+
+func TestSummarizeTemplatedInstances(t *testing.T) {
+	sdk, err := NewOffline(
+		newTestTemplatedInstance("teamA", "wordpress-db", "mysqldb", svcat.ConditionTrue, nil),
+		newTestTemplatedInstance("teamB", "ghost-db", "mysqldb", svcat.ConditionUnknown, nil),
+		newTestTemplatedInstance("teamA", "cache", "redis", svcat.ConditionTrue, nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summaries, err := sdk.SummarizeTemplatedInstances("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected a summary for each service type, got %v", summaries)
+	}
+	mysql := summaries[0]
+	if mysql.ServiceType != "mysqldb" || mysql.Ready != 1 || mysql.Pending != 1 || mysql.Total() != 2 {
+		t.Fatalf("unexpected mysqldb summary %+v", mysql)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned/fake"
+)
+
+// NewOffline creates an SDK backed by an in-memory clientset containing the
+// specified templates and templated resources, so that they can be resolved
+// without a cluster.
+func NewOffline(objects ...runtime.Object) (*SDK, error) {
+	sdk := New(fake.NewSimpleClientset(), nil, nil)
+	for _, obj := range objects {
+		if err := sdk.createObject(obj); err != nil {
+			return nil, err
+		}
+	}
+	return sdk, nil
+}
+
+func (sdk *SDK) createObject(obj runtime.Object) error {
+	var err error
+	switch o := obj.(type) {
+	case *templates.TemplatedInstance:
+		_, err = sdk.Templates().TemplatedInstances(o.Namespace).Create(o)
+	case *templates.TemplatedBinding:
+		_, err = sdk.Templates().TemplatedBindings(o.Namespace).Create(o)
+	case *templates.InstanceTemplate:
+		_, err = sdk.Templates().InstanceTemplates(o.Namespace).Create(o)
+	case *templates.ClusterInstanceTemplate:
+		_, err = sdk.Templates().ClusterInstanceTemplates().Create(o)
+	case *templates.BrokerInstanceTemplate:
+		_, err = sdk.Templates().BrokerInstanceTemplates().Create(o)
+	case *templates.BindingTemplate:
+		_, err = sdk.Templates().BindingTemplates(o.Namespace).Create(o)
+	case *templates.ClusterBindingTemplate:
+		_, err = sdk.Templates().ClusterBindingTemplates().Create(o)
+	case *templates.BrokerBindingTemplate:
+		_, err = sdk.Templates().BrokerBindingTemplates().Create(o)
+	default:
+		return fmt.Errorf("unsupported resource type %T", obj)
+	}
+	return err
+}
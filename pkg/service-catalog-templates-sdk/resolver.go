@@ -0,0 +1,381 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+)
+
+// InstanceResolution is the result of resolving a templated instance against the instance templates.
+type InstanceResolution struct {
+	// TemplatedInstance with the resolved template applied.
+	TemplatedInstance *templates.TemplatedInstance
+
+	// ServiceInstance built from the resolved templated instance.
+	// It is nil when the instance is provided by a container.
+	ServiceInstance *svcat.ServiceInstance
+
+	// Deployment built from the resolved templated instance, when it is provided by a container.
+	Deployment *apps.Deployment
+
+	// Service built from the resolved templated instance, when it is provided by a container.
+	Service *core.Service
+
+	// Templates that contributed to the resolution, ordered from least to most specific.
+	Templates []templates.InstanceTemplateInterface
+}
+
+// BindingResolution is the result of resolving a templated binding against the binding templates.
+type BindingResolution struct {
+	// TemplatedBinding with the resolved template applied.
+	TemplatedBinding *templates.TemplatedBinding
+
+	// ServiceBinding built from the resolved templated binding.
+	// It is nil when the instance is provided by a container.
+	ServiceBinding *svcat.ServiceBinding
+
+	// Secret with the credentials of the instance, when it is provided by a container.
+	Secret *core.Secret
+
+	// Templates that contributed to the resolution, ordered from least to most specific.
+	Templates []templates.BindingTemplateInterface
+}
+
+// ResolveInstance resolves the templates for a templated instance and builds
+// the service instance that would be created for it. The templated instance is not modified.
+func (sdk *SDK) ResolveInstance(tinst *templates.TemplatedInstance) (*InstanceResolution, error) {
+	template, contributors, err := sdk.resolveInstanceTemplate(tinst)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := builder.ApplyInstanceTemplate(tinst.DeepCopy(), template)
+	if err != nil {
+		return nil, errors.NewInvalidParameters("%s", err)
+	}
+
+	if builder.UsesContainerProvider(resolved) {
+		if resolved.Spec.Container == nil {
+			return nil, errors.NewTemplateNotFound("unable to resolve a container for service type: %s in namespace: %s",
+				tinst.Spec.ServiceType, tinst.Namespace)
+		}
+		return &InstanceResolution{
+			TemplatedInstance: resolved,
+			Deployment:        builder.BuildContainerDeployment(resolved),
+			Service:           builder.BuildContainerService(resolved),
+			Templates:         contributors,
+		}, nil
+	}
+
+	inst, err := builder.BuildServiceInstance(resolved)
+	if err != nil {
+		return nil, errors.NewTemplateNotFound("unable to resolve templated instance %s/%s (%s)", tinst.Namespace, tinst.Name, err)
+	}
+
+	return &InstanceResolution{
+		TemplatedInstance: resolved,
+		ServiceInstance:   inst,
+		Templates:         contributors,
+	}, nil
+}
+
+// ResolveBinding resolves the templates for a templated binding and builds
+// the service binding that would be created for it. The templated binding is not modified.
+func (sdk *SDK) ResolveBinding(tbnd *templates.TemplatedBinding) (*BindingResolution, error) {
+	template, contributors, err := sdk.resolveBindingTemplate(tbnd)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := builder.ApplyBindingTemplate(tbnd.DeepCopy(), template)
+	if err != nil {
+		return nil, errors.NewInvalidParameters("%s", err)
+	}
+
+	res := &BindingResolution{
+		TemplatedBinding: resolved,
+		Templates:        contributors,
+	}
+
+	// Bindings to a container provided instance get their credentials from the instance
+	tinst, err := sdk.GetTemplatedInstance(tbnd.Namespace, tbnd.Spec.TemplatedInstanceRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	if builder.UsesContainerProvider(tinst) && tinst.Spec.Container != nil {
+		res.Secret = builder.BuildContainerSecret(tinst, resolved)
+	} else {
+		res.ServiceBinding = builder.BuildServiceBinding(resolved)
+	}
+
+	return res, nil
+}
+
+// ResolveInstanceTemplate merges the instance templates that apply to a templated instance.
+func (sdk *SDK) ResolveInstanceTemplate(tinst *templates.TemplatedInstance) (templates.InstanceTemplateInterface, error) {
+	template, _, err := sdk.resolveInstanceTemplate(tinst)
+	return template, err
+}
+
+// ResolveBindingTemplate merges the binding templates that apply to a templated binding.
+func (sdk *SDK) ResolveBindingTemplate(tbnd templates.TemplatedBinding) (templates.BindingTemplateInterface, error) {
+	template, _, err := sdk.resolveBindingTemplate(&tbnd)
+	return template, err
+}
+
+// unresolveInstance returns a templated instance as it was requested, without what the
+// templates that apply to it today contributed when the controller resolved and saved it.
+func (sdk *SDK) unresolveInstance(tinst *templates.TemplatedInstance) (*templates.TemplatedInstance, error) {
+	template, err := sdk.ResolveInstanceTemplate(tinst)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := builder.UnapplyInstanceTemplate(tinst.DeepCopy(), template)
+	if err != nil {
+		return nil, errors.NewInvalidParameters("%s", err)
+	}
+	return request, nil
+}
+
+// InstanceTemplateSources identifies the instance templates that apply to a templated instance,
+// ordered from least to most specific.
+func (sdk *SDK) InstanceTemplateSources(tinst *templates.TemplatedInstance) ([]TemplateSource, error) {
+	_, contributors, err := sdk.resolveInstanceTemplate(tinst)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]TemplateSource, 0, len(contributors))
+	for _, t := range contributors {
+		sources = append(sources, TemplateSource{Scope: t.GetScope(), Name: t.GetName()})
+	}
+	return sources, nil
+}
+
+// BindingTemplateSources identifies the binding templates that apply to a templated binding,
+// ordered from least to most specific.
+func (sdk *SDK) BindingTemplateSources(tbnd *templates.TemplatedBinding) ([]TemplateSource, error) {
+	_, contributors, err := sdk.resolveBindingTemplate(tbnd)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]TemplateSource, 0, len(contributors))
+	for _, t := range contributors {
+		sources = append(sources, TemplateSource{Scope: t.GetScope(), Name: t.GetName()})
+	}
+	return sources, nil
+}
+
+func (sdk *SDK) resolveInstanceTemplate(tinst *templates.TemplatedInstance) (templates.InstanceTemplateInterface, []templates.InstanceTemplateInterface, error) {
+	nsTemplate, err := sdk.GetInstanceTemplateByServiceType(tinst.Spec.ServiceType, tinst.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterTemplate, err := sdk.GetClusterInstanceTemplateByServiceType(tinst.Spec.ServiceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	brokerTemplates, err := sdk.GetBrokerInstanceTemplatesByServiceType(tinst.Spec.ServiceType)
+	if err != nil {
+		return nil, nil, err
+	}
+	var brokerTemplate *templates.BrokerInstanceTemplate
+	if len(brokerTemplates.Items) == 1 {
+		brokerTemplate = &brokerTemplates.Items[0]
+	}
+
+	var template templates.InstanceTemplateInterface
+	var contributors []templates.InstanceTemplateInterface
+	if nsTemplate == nil && clusterTemplate == nil && brokerTemplate == nil {
+		if requiresInstanceTemplate(tinst) {
+			if len(brokerTemplates.Items) > 1 {
+				return nil, nil, errors.NewAmbiguousBroker("more than one broker-level instance template is defined for service type: %s and more specific templates do not exist",
+					tinst.Spec.ServiceType)
+			}
+			return nil, nil, errors.NewTemplateNotFound("unable to resolve an instance template for service type: %s in namespace: %s",
+				tinst.Spec.ServiceType, tinst.Namespace)
+		}
+
+		// Just use a blank template since it's okay to use a TemplatedInstance even when you don't need us to resolve a plan
+		// i.e. they used to use it and now have picked a plan, or maybe still need it for mapping secret keys, etc.
+		template = &templates.InstanceTemplate{}
+	} else {
+		template, err = mergeInstanceTemplates(nsTemplate, clusterTemplate, brokerTemplate)
+		if err != nil {
+			return nil, nil, errors.NewInvalidParameters("could not merge the instance templates for service type %s: %s", tinst.Spec.ServiceType, err)
+		}
+
+		if brokerTemplate != nil {
+			contributors = append(contributors, brokerTemplate)
+		}
+		if clusterTemplate != nil {
+			contributors = append(contributors, clusterTemplate)
+		}
+		if nsTemplate != nil {
+			contributors = append(contributors, nsTemplate)
+		}
+	}
+
+	// TODO: if a plan selector is specified, pick a different plan from the template's default
+	if tinst.Spec.PlanSelector != nil {
+		resolvedClass, resolvedPlan, err := sdk.resolvePlan(tinst)
+		if err != nil {
+			return nil, nil, err
+		}
+		template.SetPlanReference(svcat.PlanReference{
+			ClusterServiceClassName: resolvedClass.Name,
+			ClusterServicePlanName:  resolvedPlan.Name,
+		})
+	}
+
+	return template, contributors, nil
+}
+
+func requiresInstanceTemplate(inst *templates.TemplatedInstance) bool {
+	if builder.UsesContainerProvider(inst) {
+		return inst.Spec.Container == nil
+	}
+
+	if (inst.Spec.ClusterServiceClassName != "" || inst.Spec.ClusterServiceClassExternalName != "") &&
+		(inst.Spec.ClusterServicePlanName != "" || inst.Spec.ClusterServicePlanExternalName != "") {
+		return false
+	}
+
+	return true
+}
+
+func (sdk *SDK) resolveBindingTemplate(tbnd *templates.TemplatedBinding) (templates.BindingTemplateInterface, []templates.BindingTemplateInterface, error) {
+	tinst, err := sdk.GetTemplatedInstance(tbnd.Namespace, tbnd.Spec.TemplatedInstanceRef.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nsTemplate, err := sdk.GetBindingTemplateByServiceType(tinst.Spec.ServiceType, tinst.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterTemplate, err := sdk.GetClusterBindingTemplateByServiceType(tinst.Spec.ServiceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	brokerTemplates, err := sdk.GetBrokerBindingTemplatesByServiceType(tinst.Spec.ServiceType)
+	if err != nil {
+		return nil, nil, err
+	}
+	var brokerTemplate *templates.BrokerBindingTemplate
+	if len(brokerTemplates.Items) == 1 {
+		brokerTemplate = &brokerTemplates.Items[0]
+	}
+
+	var template templates.BindingTemplateInterface
+	var contributors []templates.BindingTemplateInterface
+	if nsTemplate == nil && clusterTemplate == nil && brokerTemplate == nil {
+		// Just use a blank template
+		template = &templates.BindingTemplate{}
+	} else {
+		template, err = mergeBindingTemplates(nsTemplate, clusterTemplate, brokerTemplate)
+		if err != nil {
+			return nil, nil, errors.NewInvalidParameters("could not merge the binding templates for service type %s: %s", tinst.Spec.ServiceType, err)
+		}
+
+		if brokerTemplate != nil {
+			contributors = append(contributors, brokerTemplate)
+		}
+		if clusterTemplate != nil {
+			contributors = append(contributors, clusterTemplate)
+		}
+		if nsTemplate != nil {
+			contributors = append(contributors, nsTemplate)
+		}
+	}
+
+	return template, contributors, nil
+}
+
+func (sdk *SDK) resolvePlan(instance *templates.TemplatedInstance) (*svcat.ClusterServiceClass, *svcat.ClusterServicePlan, error) {
+	// TODO: using the plan selector and type select a matching plan
+	return nil, nil, nil
+}
+
+func mergeInstanceTemplates(namespaceTemplate *templates.InstanceTemplate,
+	clusterTemplate *templates.ClusterInstanceTemplate, brokerTemplate *templates.BrokerInstanceTemplate,
+) (templates.InstanceTemplateInterface, error) {
+	template := &templates.InstanceTemplate{}
+
+	if brokerTemplate != nil {
+		template.Spec.PlanReference = brokerTemplate.Spec.PlanReference
+		template.Spec.Parameters = brokerTemplate.Spec.Parameters
+		template.Spec.ParametersFrom = brokerTemplate.Spec.ParametersFrom
+		template.Spec.Provider = brokerTemplate.Spec.Provider
+		template.Spec.Container = brokerTemplate.Spec.Container
+	}
+
+	var err error
+	if clusterTemplate != nil {
+		template.Spec.Parameters, err = builder.MergeParameters(template.Spec.Parameters, clusterTemplate.Spec.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		template.Spec.ParametersFrom = builder.MergeParametersFromSource(template.Spec.ParametersFrom, clusterTemplate.Spec.ParametersFrom)
+		template.Spec.PlanReference = builder.MergePlanReference(template.Spec.PlanReference, clusterTemplate.Spec.PlanReference)
+		template.Spec.Provider, template.Spec.Container = builder.MergeProvider(template.Spec.Provider, template.Spec.Container,
+			clusterTemplate.Spec.Provider, clusterTemplate.Spec.Container)
+	}
+
+	if namespaceTemplate != nil {
+		template.Spec.Parameters, err = builder.MergeParameters(template.Spec.Parameters, namespaceTemplate.Spec.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		template.Spec.ParametersFrom = builder.MergeParametersFromSource(template.Spec.ParametersFrom, namespaceTemplate.Spec.ParametersFrom)
+		template.Spec.PlanReference = builder.MergePlanReference(template.Spec.PlanReference, namespaceTemplate.Spec.PlanReference)
+		template.Spec.Provider, template.Spec.Container = builder.MergeProvider(template.Spec.Provider, template.Spec.Container,
+			namespaceTemplate.Spec.Provider, namespaceTemplate.Spec.Container)
+	}
+
+	return template, nil
+}
+
+func mergeBindingTemplates(namespaceTemplate *templates.BindingTemplate,
+	clusterTemplate *templates.ClusterBindingTemplate, brokerTemplate *templates.BrokerBindingTemplate,
+) (templates.BindingTemplateInterface, error) {
+	template := &templates.BindingTemplate{}
+
+	if brokerTemplate != nil {
+		template.Spec.Parameters = brokerTemplate.Spec.Parameters
+		template.Spec.ParametersFrom = brokerTemplate.Spec.ParametersFrom
+		template.Spec.SecretKeys = brokerTemplate.Spec.SecretKeys
+	}
+
+	var err error
+	if clusterTemplate != nil {
+		template.Spec.Parameters, err = builder.MergeParameters(template.Spec.Parameters, clusterTemplate.Spec.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		template.Spec.ParametersFrom = builder.MergeParametersFromSource(template.Spec.ParametersFrom, clusterTemplate.Spec.ParametersFrom)
+		template.Spec.SecretKeys = builder.MergeSecretKeys(template.Spec.SecretKeys, clusterTemplate.Spec.SecretKeys)
+	}
+
+	if namespaceTemplate != nil {
+		template.Spec.Parameters, err = builder.MergeParameters(template.Spec.Parameters, namespaceTemplate.Spec.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		template.Spec.ParametersFrom = builder.MergeParametersFromSource(template.Spec.ParametersFrom, namespaceTemplate.Spec.ParametersFrom)
+		template.Spec.SecretKeys = builder.MergeSecretKeys(template.Spec.SecretKeys, namespaceTemplate.Spec.SecretKeys)
+	}
+
+	return template, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"testing"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned/fake"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestResolveInstance(t *testing.T) {
+	brokerTemplate := &templates.BrokerInstanceTemplate{
+		ObjectMeta: meta.ObjectMeta{
+			Name:   "osba-mysqldb",
+			Labels: map[string]string{templates.FieldServiceTypeName: "mysqldb"},
+		},
+		Spec: templates.BrokerInstanceTemplateSpec{
+			BrokerName: "osba",
+			InstanceTemplateSpec: templates.InstanceTemplateSpec{
+				ServiceType: "mysqldb",
+				PlanReference: svcat.PlanReference{
+					ClusterServiceClassExternalName: "azure-mysql",
+					ClusterServicePlanExternalName:  "basic50",
+				},
+				Parameters: &runtime.RawExtension{Raw: []byte(`{"location":"eastus","sslEnforcement":"disabled"}`)},
+			},
+		},
+	}
+	nsTemplate := &templates.InstanceTemplate{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "mysqldb",
+			Namespace: "ci",
+			Labels:    map[string]string{templates.FieldServiceTypeName: "mysqldb"},
+		},
+		Spec: templates.InstanceTemplateSpec{
+			ServiceType: "mysqldb",
+			PlanReference: svcat.PlanReference{
+				ClusterServiceClassExternalName: "azure-mysql",
+				ClusterServicePlanExternalName:  "standard100",
+			},
+			Parameters: &runtime.RawExtension{Raw: []byte(`{"location":"westus"}`)},
+		},
+	}
+	otherTemplate := &templates.InstanceTemplate{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      "redis",
+			Namespace: "ci",
+			Labels:    map[string]string{templates.FieldServiceTypeName: "redis"},
+		},
+		Spec: templates.InstanceTemplateSpec{
+			ServiceType: "redis",
+		},
+	}
+	sdk := New(fake.NewSimpleClientset(brokerTemplate, nsTemplate, otherTemplate), nil, nil)
+
+	tinst := &templates.TemplatedInstance{
+		ObjectMeta: meta.ObjectMeta{Name: "wordpress-mysql", Namespace: "ci"},
+		Spec:       templates.TemplatedInstanceSpec{ServiceType: "mysqldb"},
+	}
+	res, err := sdk.ResolveInstance(tinst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Templates) != 2 || res.Templates[0].GetName() != "osba-mysqldb" || res.Templates[1].GetName() != "mysqldb" {
+		t.Fatalf("expected the broker then namespace templates to contribute, got %#v", res.Templates)
+	}
+
+	pr := res.ServiceInstance.Spec.PlanReference
+	if pr.ClusterServiceClassExternalName != "azure-mysql" || pr.ClusterServicePlanExternalName != "standard100" {
+		t.Fatalf("expected the namespace template to override the plan, got %#v", pr)
+	}
+
+	wantParams := `{"location":"westus","sslEnforcement":"disabled"}`
+	if got := string(res.ServiceInstance.Spec.Parameters.Raw); got != wantParams {
+		t.Fatalf("expected parameters %s got %s", wantParams, got)
+	}
+
+	if tinst.Spec.Parameters != nil {
+		t.Fatal("expected the templated instance to not be modified")
+	}
+}
+
+func TestResolveInstanceErrors(t *testing.T) {
+	brokerTemplate := func(broker string) *templates.BrokerInstanceTemplate {
+		return &templates.BrokerInstanceTemplate{
+			ObjectMeta: meta.ObjectMeta{
+				Name:   broker + "-mysqldb",
+				Labels: map[string]string{templates.FieldServiceTypeName: "mysqldb"},
+			},
+			Spec: templates.BrokerInstanceTemplateSpec{
+				BrokerName:           broker,
+				InstanceTemplateSpec: templates.InstanceTemplateSpec{ServiceType: "mysqldb"},
+			},
+		}
+	}
+	sdk := New(fake.NewSimpleClientset(brokerTemplate("osba"), brokerTemplate("other")), nil, nil)
+
+	testcases := map[string]struct {
+		serviceType string
+		reason      errors.Reason
+	}{
+		"template not found": {"redis", errors.ReasonTemplateNotFound},
+		"ambiguous broker":   {"mysqldb", errors.ReasonAmbiguousBroker},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			tinst := &templates.TemplatedInstance{
+				ObjectMeta: meta.ObjectMeta{Name: "wordpress", Namespace: "ci"},
+				Spec:       templates.TemplatedInstanceSpec{ServiceType: tc.serviceType},
+			}
+			_, err := sdk.ResolveInstance(tinst)
+			if got := errors.Classify(err); got == nil || got.Reason != tc.reason || got.Retry {
+				t.Fatalf("expected a %s error that is not retried, got %#v", tc.reason, got)
+			}
+		})
+	}
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-sdk"
 	"github.com/golang/glog"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
 
 	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
@@ -69,6 +71,12 @@ func (sdk *SDK) Cache() templatesinformer.Interface {
 	return sdk.informers
 }
 
+// HasCache determines if the SDK was initialized with an informer factory,
+// otherwise all reads go to the API server.
+func (sdk *SDK) HasCache() bool {
+	return sdk.Factory != nil
+}
+
 func (sdk *SDK) InstanceCache() templateslisters.TemplatedInstanceLister {
 	if sdk.templatedInstanceLister == nil {
 		sdk.templatedInstanceLister = sdk.Cache().TemplatedInstances().Lister()
@@ -92,3 +100,20 @@ func filterByServiceTypeLabel(serviceType string) meta.ListOptions {
 	}
 	return opts
 }
+
+// setServiceTypeLabel labels a template with its service type, so that it is
+// found by filterByServiceTypeLabel.
+func setServiceTypeLabel(obj runtime.Object, serviceType string) error {
+	accessor, err := apimeta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	labels := accessor.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[templates.FieldServiceTypeName] = serviceType
+	accessor.SetLabels(labels)
+	return nil
+}
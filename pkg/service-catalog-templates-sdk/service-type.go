@@ -0,0 +1,407 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// ServiceTypeDescription is the effective configuration that the templated
+// resources of a service type get in a namespace, with the template that each
+// value came from.
+type ServiceTypeDescription struct {
+	ServiceType string `json:"serviceType"`
+	Namespace   string `json:"namespace"`
+
+	Instance EffectiveInstanceTemplate `json:"instance"`
+	Binding  EffectiveBindingTemplate  `json:"binding"`
+
+	// Warnings about templates that are ambiguous, or whose settings are shadowed.
+	Warnings []string `json:"warnings"`
+}
+
+// EffectiveInstanceTemplate is the merge of the instance templates of a service type.
+type EffectiveInstanceTemplate struct {
+	// Templates that contributed to the merge, ordered from least to most specific.
+	Templates      []TemplateSource `json:"templates"`
+	Provider       *SourcedValue    `json:"provider,omitempty"`
+	Class          *SourcedValue    `json:"class,omitempty"`
+	Plan           *SourcedValue    `json:"plan,omitempty"`
+	Parameters     []SourcedValue   `json:"parameters"`
+	ParametersFrom []SourcedValue   `json:"parametersFrom"`
+}
+
+// EffectiveBindingTemplate is the merge of the binding templates of a service type.
+type EffectiveBindingTemplate struct {
+	// Templates that contributed to the merge, ordered from least to most specific.
+	Templates      []TemplateSource `json:"templates"`
+	Parameters     []SourcedValue   `json:"parameters"`
+	ParametersFrom []SourcedValue   `json:"parametersFrom"`
+	SecretKeys     []SourcedValue   `json:"secretKeys"`
+}
+
+// TemplateSource identifies a template.
+type TemplateSource struct {
+	Scope templates.TemplateScope `json:"scope"`
+	Name  string                  `json:"name"`
+}
+
+func (s TemplateSource) String() string {
+	return fmt.Sprintf("%s template %s", s.Scope, s.Name)
+}
+
+// SourcedValue is an effective value and the template that it came from.
+// Parameter values are JSON.
+type SourcedValue struct {
+	Name   string         `json:"name,omitempty"`
+	Value  string         `json:"value"`
+	Source TemplateSource `json:"source"`
+}
+
+// templateLayer is the part of a template that is merged, for either kind of template.
+type templateLayer struct {
+	source         TemplateSource
+	planReference  svcat.PlanReference
+	provider       templates.Provider
+	parameters     *runtime.RawExtension
+	parametersFrom []svcat.ParametersFromSource
+	secretKeys     map[string]string
+}
+
+// DescribeServiceType merges the templates of a service type, like the resolver
+// does for the templated resources in a namespace, and reports where each
+// effective value came from.
+func (sdk *SDK) DescribeServiceType(ns, serviceType string) (*ServiceTypeDescription, error) {
+	d := &ServiceTypeDescription{
+		ServiceType: serviceType,
+		Namespace:   ns,
+		Instance: EffectiveInstanceTemplate{
+			Templates:      []TemplateSource{},
+			Parameters:     []SourcedValue{},
+			ParametersFrom: []SourcedValue{},
+		},
+		Binding: EffectiveBindingTemplate{
+			Templates:      []TemplateSource{},
+			Parameters:     []SourcedValue{},
+			ParametersFrom: []SourcedValue{},
+			SecretKeys:     []SourcedValue{},
+		},
+		Warnings: []string{},
+	}
+
+	instanceLayers, err := sdk.describeInstanceTemplates(d)
+	if err != nil {
+		return nil, err
+	}
+	bindingLayers, err := sdk.describeBindingTemplates(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instanceLayers) == 0 && len(bindingLayers) == 0 {
+		d.Warnings = append(d.Warnings, fmt.Sprintf("no templates apply to service type %s in namespace %s", serviceType, ns))
+	}
+	return d, nil
+}
+
+func (sdk *SDK) describeInstanceTemplates(d *ServiceTypeDescription) ([]templateLayer, error) {
+	nsTemplate, err := sdk.GetInstanceTemplateByServiceType(d.ServiceType, d.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusterTemplate, err := sdk.GetClusterInstanceTemplateByServiceType(d.ServiceType)
+	if err != nil {
+		return nil, err
+	}
+	brokerTemplates, err := sdk.GetBrokerInstanceTemplatesByServiceType(d.ServiceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []templateLayer
+	var brokerTemplate *templates.BrokerInstanceTemplate
+	if len(brokerTemplates.Items) == 1 {
+		brokerTemplate = &brokerTemplates.Items[0]
+		layers = append(layers, instanceLayer(brokerTemplate))
+	} else if len(brokerTemplates.Items) > 1 {
+		d.Warnings = append(d.Warnings, ambiguousBrokerWarning("instance", brokerTemplates.Items[0].GetName(), len(brokerTemplates.Items)))
+	}
+	if clusterTemplate != nil {
+		layers = append(layers, instanceLayer(clusterTemplate))
+	}
+	if nsTemplate != nil {
+		layers = append(layers, instanceLayer(nsTemplate))
+	}
+	if len(layers) == 0 {
+		return nil, nil
+	}
+
+	merged, err := mergeInstanceTemplates(nsTemplate, clusterTemplate, brokerTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not merge the instance templates for service type %s: %s", d.ServiceType, err)
+	}
+
+	effective := &d.Instance
+	for _, layer := range layers {
+		effective.Templates = append(effective.Templates, layer.source)
+	}
+
+	pr := merged.GetPlanReference()
+	effective.Class, effective.Plan = describePlan(layers, pr, &d.Warnings)
+	if provider := merged.GetProvider(); provider != "" {
+		effective.Provider = describeValue(layers, string(provider), func(l templateLayer) string { return string(l.provider) })
+	}
+	effective.Parameters, err = describeParameters(layers, merged.GetParameters(), &d.Warnings)
+	if err != nil {
+		return nil, err
+	}
+	effective.ParametersFrom = describeParametersFrom(layers, merged.GetParametersFrom(), &d.Warnings)
+	return layers, nil
+}
+
+func (sdk *SDK) describeBindingTemplates(d *ServiceTypeDescription) ([]templateLayer, error) {
+	nsTemplate, err := sdk.GetBindingTemplateByServiceType(d.ServiceType, d.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusterTemplate, err := sdk.GetClusterBindingTemplateByServiceType(d.ServiceType)
+	if err != nil {
+		return nil, err
+	}
+	brokerTemplates, err := sdk.GetBrokerBindingTemplatesByServiceType(d.ServiceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []templateLayer
+	var brokerTemplate *templates.BrokerBindingTemplate
+	if len(brokerTemplates.Items) == 1 {
+		brokerTemplate = &brokerTemplates.Items[0]
+		layers = append(layers, bindingLayer(brokerTemplate))
+	} else if len(brokerTemplates.Items) > 1 {
+		d.Warnings = append(d.Warnings, ambiguousBrokerWarning("binding", brokerTemplates.Items[0].GetName(), len(brokerTemplates.Items)))
+	}
+	if clusterTemplate != nil {
+		layers = append(layers, bindingLayer(clusterTemplate))
+	}
+	if nsTemplate != nil {
+		layers = append(layers, bindingLayer(nsTemplate))
+	}
+	if len(layers) == 0 {
+		return nil, nil
+	}
+
+	merged, err := mergeBindingTemplates(nsTemplate, clusterTemplate, brokerTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not merge the binding templates for service type %s: %s", d.ServiceType, err)
+	}
+
+	effective := &d.Binding
+	for _, layer := range layers {
+		effective.Templates = append(effective.Templates, layer.source)
+	}
+
+	effective.Parameters, err = describeParameters(layers, merged.GetParameters(), &d.Warnings)
+	if err != nil {
+		return nil, err
+	}
+	effective.ParametersFrom = describeParametersFrom(layers, merged.GetParametersFrom(), &d.Warnings)
+	effective.SecretKeys = describeSecretKeys(layers, merged.GetSecretKeys(), &d.Warnings)
+	return layers, nil
+}
+
+func instanceLayer(t templates.InstanceTemplateInterface) templateLayer {
+	return templateLayer{
+		source:         TemplateSource{Scope: t.GetScope(), Name: t.GetName()},
+		planReference:  t.GetPlanReference(),
+		provider:       t.GetProvider(),
+		parameters:     t.GetParameters(),
+		parametersFrom: t.GetParametersFrom(),
+	}
+}
+
+func bindingLayer(t templates.BindingTemplateInterface) templateLayer {
+	return templateLayer{
+		source:         TemplateSource{Scope: t.GetScope(), Name: t.GetName()},
+		parameters:     t.GetParameters(),
+		parametersFrom: t.GetParametersFrom(),
+		secretKeys:     t.GetSecretKeys(),
+	}
+}
+
+func ambiguousBrokerWarning(kind, example string, count int) string {
+	return fmt.Sprintf("%d broker-level %s templates, e.g. %s, are defined for the service type, so none of them apply", count, kind, example)
+}
+
+// describeValue attributes an effective value to the most specific template that sets it.
+func describeValue(layers []templateLayer, value string, get func(templateLayer) string) *SourcedValue {
+	for i := len(layers) - 1; i >= 0; i-- {
+		if get(layers[i]) == value {
+			return &SourcedValue{Value: value, Source: layers[i].source}
+		}
+	}
+	return nil
+}
+
+// describePlan attributes the class and plan. A template after the least specific
+// one only overrides the plan when it specifies both a class and a plan.
+func describePlan(layers []templateLayer, pr svcat.PlanReference, warnings *[]string) (*SourcedValue, *SourcedValue) {
+	var last *templateLayer
+	for i := range layers {
+		layer := &layers[i]
+		className, planName := planReferenceNames(layer.planReference)
+		if className == "" && planName == "" {
+			continue
+		}
+		if i > 0 && (className == "" || planName == "") {
+			*warnings = append(*warnings, fmt.Sprintf("the plan of %s is ignored because it does not specify both a class and a plan", layer.source))
+			continue
+		}
+		if last != nil && !reflect.DeepEqual(last.planReference, layer.planReference) {
+			*warnings = append(*warnings, fmt.Sprintf("the plan of %s is overridden by %s", last.source, layer.source))
+		}
+		last = layer
+	}
+
+	className, planName := planReferenceNames(pr)
+	var class, plan *SourcedValue
+	if className != "" {
+		class = describeValue(layers, className, func(l templateLayer) string { n, _ := planReferenceNames(l.planReference); return n })
+	}
+	if planName != "" {
+		plan = describeValue(layers, planName, func(l templateLayer) string { _, n := planReferenceNames(l.planReference); return n })
+	}
+	return class, plan
+}
+
+func planReferenceNames(pr svcat.PlanReference) (string, string) {
+	className := pr.ClusterServiceClassExternalName
+	if className == "" {
+		className = pr.ClusterServiceClassName
+	}
+	planName := pr.ClusterServicePlanExternalName
+	if planName == "" {
+		planName = pr.ClusterServicePlanName
+	}
+	return className, planName
+}
+
+// describeParameters attributes each top-level parameter to the most specific
+// template that sets it, and warns about the values that it overrides.
+func describeParameters(layers []templateLayer, merged *runtime.RawExtension, warnings *[]string) ([]SourcedValue, error) {
+	values := []SourcedValue{}
+	if merged == nil {
+		return values, nil
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal(merged.Raw, &params); err != nil {
+		return nil, err
+	}
+
+	layerParams := make([]map[string]interface{}, len(layers))
+	for i, layer := range layers {
+		if layer.parameters != nil {
+			json.Unmarshal(layer.parameters.Raw, &layerParams[i])
+		}
+	}
+
+	for _, name := range sortedKeys(params) {
+		value, err := json.Marshal(params[name])
+		if err != nil {
+			return nil, err
+		}
+
+		source := -1
+		for i := len(layers) - 1; i >= 0; i-- {
+			layerValue, ok := layerParams[i][name]
+			if !ok {
+				continue
+			}
+			if source == -1 {
+				source = i
+				values = append(values, SourcedValue{Name: name, Value: string(value), Source: layers[i].source})
+			} else if !reflect.DeepEqual(layerValue, layerParams[source][name]) {
+				*warnings = append(*warnings, fmt.Sprintf("parameter %s of %s is overridden by %s", name, layers[i].source, layers[source].source))
+			}
+		}
+	}
+	return values, nil
+}
+
+// describeParametersFrom attributes the parameters from secrets. The least
+// specific template that sets them wins, the others are ignored.
+func describeParametersFrom(layers []templateLayer, merged []svcat.ParametersFromSource, warnings *[]string) []SourcedValue {
+	values := []SourcedValue{}
+	var source *templateLayer
+	for i := range layers {
+		if len(layers[i].parametersFrom) == 0 {
+			continue
+		}
+		if source == nil {
+			source = &layers[i]
+			continue
+		}
+		*warnings = append(*warnings, fmt.Sprintf("the parametersFrom of %s are ignored because %s already sets them", layers[i].source, source.source))
+	}
+	if source == nil {
+		return values
+	}
+
+	for _, p := range merged {
+		if p.SecretKeyRef == nil {
+			continue
+		}
+		values = append(values, SourcedValue{Name: p.SecretKeyRef.Name, Value: p.SecretKeyRef.Key, Source: source.source})
+	}
+	return values
+}
+
+// describeSecretKeys attributes each secret key mapping to the most specific
+// template that sets it, and warns about the mappings that it overrides.
+func describeSecretKeys(layers []templateLayer, merged map[string]string, warnings *[]string) []SourcedValue {
+	values := []SourcedValue{}
+	for _, key := range sortedStringKeys(merged) {
+		var source *templateLayer
+		for i := len(layers) - 1; i >= 0; i-- {
+			mappedKey, ok := layers[i].secretKeys[key]
+			if !ok {
+				continue
+			}
+			if source == nil {
+				source = &layers[i]
+				values = append(values, SourcedValue{Name: key, Value: merged[key], Source: source.source})
+			} else if mappedKey != merged[key] {
+				*warnings = append(*warnings, fmt.Sprintf("secret key %s of %s is overridden by %s", key, layers[i].source, source.source))
+			}
+		}
+	}
+	return values
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"testing"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestDescribeServiceType(t *testing.T) {
+	labels := map[string]string{templates.FieldServiceTypeName: "mysqldb"}
+	sdk, err := NewOffline(
+		&templates.BrokerInstanceTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: "osba-mysqldb", Labels: labels},
+			Spec: templates.BrokerInstanceTemplateSpec{
+				BrokerName: "osba",
+				InstanceTemplateSpec: templates.InstanceTemplateSpec{
+					ServiceType: "mysqldb",
+					PlanReference: svcat.PlanReference{
+						ClusterServiceClassExternalName: "azure-mysql",
+						ClusterServicePlanExternalName:  "basic50",
+					},
+					Parameters: &runtime.RawExtension{Raw: []byte(`{"location":"eastus","sslEnforcement":"disabled"}`)},
+				},
+			},
+		},
+		&templates.InstanceTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: "mysqldb", Namespace: "ci", Labels: labels},
+			Spec: templates.InstanceTemplateSpec{
+				ServiceType: "mysqldb",
+				PlanReference: svcat.PlanReference{
+					ClusterServicePlanExternalName: "standard100",
+				},
+				Parameters: &runtime.RawExtension{Raw: []byte(`{"location":"westus"}`)},
+			},
+		},
+		&templates.ClusterBindingTemplate{
+			ObjectMeta: meta.ObjectMeta{Name: "mysqldb", Labels: labels},
+			Spec: templates.ClusterBindingTemplateSpec{
+				ServiceType: "mysqldb",
+				SecretKeys:  map[string]string{"host": "MYSQL_HOST"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := sdk.DescribeServiceType("ci", "mysqldb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := TemplateSource{Scope: templates.ScopeBroker, Name: "osba-mysqldb"}
+	namespace := TemplateSource{Scope: templates.ScopeNamespace, Name: "mysqldb"}
+	if d.Instance.Plan == nil || d.Instance.Plan.Value != "basic50" || d.Instance.Plan.Source != broker {
+		t.Fatalf("expected the plan of the broker template, got %+v", d.Instance.Plan)
+	}
+
+	sources := map[string]SourcedValue{}
+	for _, p := range d.Instance.Parameters {
+		sources[p.Name] = p
+	}
+	if p := sources["location"]; p.Value != `"westus"` || p.Source != namespace {
+		t.Fatalf("expected the location parameter of the namespace template, got %+v", p)
+	}
+	if p := sources["sslEnforcement"]; p.Source != broker {
+		t.Fatalf("expected the sslEnforcement parameter of the broker template, got %+v", p)
+	}
+
+	if len(d.Binding.SecretKeys) != 1 || d.Binding.SecretKeys[0].Source.Scope != templates.ScopeCluster {
+		t.Fatalf("expected the secret key of the cluster binding template, got %+v", d.Binding.SecretKeys)
+	}
+
+	// The partial plan of the namespace template and the overridden location are reported
+	if len(d.Warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %q", d.Warnings)
+	}
+}
@@ -23,7 +23,7 @@ func (sdk *SDK) GetManagedServiceBinding(tbnd *templates.TemplatedBinding) (bnd
 	}
 
 	if !meta.IsControlledBy(bnd, tbnd) {
-		return nil, errors.NewUnmanagedResource()
+		return nil, errors.NewUnmanagedResource(bnd.Name)
 	}
 
 	return bnd, nil
@@ -50,12 +50,7 @@ func (sdk *SDK) GetBindingOwner(svcBnd *servicecatalog.ServiceBinding) (*templat
 
 // RetrieveBindings lists all bindings in a namespace.
 func (sdk *SDK) RetrieveTemplatedBindings(ns string) (*templates.TemplatedBindingList, error) {
-	bindings, err := sdk.Templates().TemplatedBindings(ns).List(meta.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list bindings in %s (%s)", ns, err)
-	}
-
-	return bindings, nil
+	return sdk.ListTemplatedBindings(ns, TemplatedListOptions{})
 }
 
 // RetrieveBinding gets a binding by its name.
@@ -71,7 +66,7 @@ func (sdk *SDK) RetrieveTemplatedBinding(ns, name string) (*templates.TemplatedB
 func (sdk *SDK) RetrieveTemplatedBindingsByInstance(instance *templates.TemplatedInstance,
 ) ([]templates.TemplatedBinding, error) {
 	// Not using a filtered list operation because it's not supported yet.
-	results, err := sdk.Templates().TemplatedBindings(instance.Namespace).List(meta.ListOptions{})
+	results, err := sdk.ListTemplatedBindings(instance.Namespace, TemplatedListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("unable to search bindings (%s)", err)
 	}
@@ -90,13 +85,40 @@ func (sdk *SDK) RetrieveTemplatedBindingsByInstance(instance *templates.Template
 func (sdk *SDK) Bind(namespace, bindingName, instanceName, secretName string,
 	params map[string]string, secrets map[string]string) (*templates.TemplatedBinding, error) {
 
+	request := buildBindRequest(namespace, bindingName, instanceName, secretName, params, secrets)
+
+	result, err := sdk.Templates().TemplatedBindings(namespace).Create(request)
+	if err != nil {
+		return nil, fmt.Errorf("bind request failed (%s)", err)
+	}
+
+	return result, nil
+}
+
+// DryRunBind resolves the service binding that would be created by Bind, without creating anything.
+func (sdk *SDK) DryRunBind(namespace, bindingName, instanceName, secretName string,
+	params map[string]string, secrets map[string]string) (*BindingResolution, error) {
+
+	request := buildBindRequest(namespace, bindingName, instanceName, secretName, params, secrets)
+
+	result, err := sdk.ResolveBinding(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve templated binding %s/%s (%s)", namespace, request.Name, err)
+	}
+
+	return result, nil
+}
+
+func buildBindRequest(namespace, bindingName, instanceName, secretName string,
+	params map[string]string, secrets map[string]string) *templates.TemplatedBinding {
+
 	// Manually defaulting the name of the binding
 	// I'm not doing the same for the secret since the API handles defaulting that value.
 	if bindingName == "" {
 		bindingName = instanceName
 	}
 
-	request := &templates.TemplatedBinding{
+	return &templates.TemplatedBinding{
 		ObjectMeta: meta.ObjectMeta{
 			Name:      bindingName,
 			Namespace: namespace,
@@ -110,13 +132,6 @@ func (sdk *SDK) Bind(namespace, bindingName, instanceName, secretName string,
 			ParametersFrom: svcat.BuildParametersFrom(secrets),
 		},
 	}
-
-	result, err := sdk.Templates().TemplatedBindings(namespace).Create(request)
-	if err != nil {
-		return nil, fmt.Errorf("bind request failed (%s)", err)
-	}
-
-	return result, nil
 }
 
 // Unbind deletes all bindings associated to an instance.
@@ -8,6 +8,7 @@ import (
 
 	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
 	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	svcat "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -21,7 +22,7 @@ func (sdk *SDK) GetManagedServiceInstance(tinst *templates.TemplatedInstance) (i
 	}
 
 	if !meta.IsControlledBy(inst, tinst) {
-		return nil, errors.NewUnmanagedResource()
+		return nil, errors.NewUnmanagedResource(inst.Name)
 	}
 
 	return inst, nil
@@ -36,14 +37,18 @@ func (sdk *SDK) GetInstanceFromCache(namespace, name string) (*templates.Templat
 	return inst.DeepCopy(), nil
 }
 
-// RetrieveTemplatedInstances lists all instances in a namespace.
-func (sdk *SDK) RetrieveTemplatedInstances(ns string) (*templates.TemplatedInstanceList, error) {
-	instances, err := sdk.Templates().TemplatedInstances(ns).List(meta.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list templated instances in %s (%s)", ns, err)
+// GetTemplatedInstance retrieves a TemplatedInstance from the informer cache when available,
+// otherwise from the API server.
+func (sdk *SDK) GetTemplatedInstance(namespace, name string) (*templates.TemplatedInstance, error) {
+	if sdk.HasCache() {
+		return sdk.GetInstanceFromCache(namespace, name)
 	}
+	return sdk.Templates().TemplatedInstances(namespace).Get(name, meta.GetOptions{})
+}
 
-	return instances, nil
+// RetrieveTemplatedInstances lists all instances in a namespace.
+func (sdk *SDK) RetrieveTemplatedInstances(ns string) (*templates.TemplatedInstanceList, error) {
+	return sdk.ListTemplatedInstances(ns, TemplatedListOptions{})
 }
 
 // RetrieveTemplatedInstance gets an instance by its name.
@@ -82,7 +87,56 @@ func (sdk *SDK) TemplatedInstanceParentHierarchy(tinst *templates.TemplatedInsta
 func (sdk *SDK) Provision(namespace, instanceName, serviceType, className, planName string,
 	params interface{}, secrets map[string]string) (*templates.TemplatedInstance, error) {
 
-	request := &templates.TemplatedInstance{
+	request := buildProvisionRequest(namespace, instanceName, serviceType, className, planName, params, secrets)
+
+	result, err := sdk.Templates().TemplatedInstances(namespace).Create(request)
+	if err != nil {
+		return nil, fmt.Errorf("provision request failed (%s)", err)
+	}
+	return result, nil
+}
+
+// ProvisionAndBind creates an instance and a binding to it, both named after the instance.
+// When the binding cannot be created the instance is deleted, so that nothing is left half created.
+func (sdk *SDK) ProvisionAndBind(namespace, instanceName, serviceType, className, planName string,
+	params interface{}, secrets map[string]string, secretName string) (*templates.TemplatedInstance, *templates.TemplatedBinding, error) {
+
+	tinst, err := sdk.Provision(namespace, instanceName, serviceType, className, planName, params, secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tbnd, err := sdk.Bind(namespace, instanceName, instanceName, secretName, nil, nil)
+	if err != nil {
+		if rollbackErr := sdk.Deprovision(namespace, instanceName); rollbackErr != nil {
+			return nil, nil, fmt.Errorf("%s, and the instance %s/%s could not be rolled back (%s)", err, namespace, instanceName, rollbackErr)
+		}
+		return nil, nil, fmt.Errorf("%s, the instance %s/%s was rolled back", err, namespace, instanceName)
+	}
+
+	return tinst, tbnd, nil
+}
+
+// DryRunProvision resolves the service instance that would be created by Provision, without creating anything.
+// The provider is the one selected by the label of the namespace, which the controller applies before the
+// templates, so that the resolution matches what the controller creates.
+func (sdk *SDK) DryRunProvision(namespace, instanceName, serviceType, className, planName string,
+	params interface{}, secrets map[string]string, provider templates.Provider) (*InstanceResolution, error) {
+
+	request := buildProvisionRequest(namespace, instanceName, serviceType, className, planName, params, secrets)
+	request.Spec.Provider = provider
+
+	result, err := sdk.ResolveInstance(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve templated instance %s/%s (%s)", namespace, instanceName, err)
+	}
+	return result, nil
+}
+
+func buildProvisionRequest(namespace, instanceName, serviceType, className, planName string,
+	params interface{}, secrets map[string]string) *templates.TemplatedInstance {
+
+	return &templates.TemplatedInstance{
 		ObjectMeta: meta.ObjectMeta{
 			Name:      instanceName,
 			Namespace: namespace,
@@ -97,12 +151,6 @@ func (sdk *SDK) Provision(namespace, instanceName, serviceType, className, planN
 			ParametersFrom: svcat.BuildParametersFrom(secrets),
 		},
 	}
-
-	result, err := sdk.Templates().TemplatedInstances(namespace).Create(request)
-	if err != nil {
-		return nil, fmt.Errorf("provision request failed (%s)", err)
-	}
-	return result, nil
 }
 
 // Deprovision deletes an instance.
@@ -117,24 +165,170 @@ func (sdk *SDK) Deprovision(namespace, instanceName string) error {
 // TouchTemplatedInstance increments the updateRequests field on an instance to make
 // service process it again (might be an update, delete, or noop)
 func (sdk *SDK) TouchTemplatedInstance(ns, name string, retries int) error {
+	_, err := sdk.retryTemplatedInstanceUpdate(ns, name, retries, func(inst *templates.TemplatedInstance) error {
+		inst.Spec.UpdateRequests = inst.Spec.UpdateRequests + 1
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not touch templated instance (%s)", err)
+	}
+	return nil
+}
+
+// TemplatedInstanceUpdate holds the changes to make to a templated instance.
+// Empty fields leave the instance unchanged.
+type TemplatedInstanceUpdate struct {
+	// ServiceType re-resolves the plan and parameters from the templates of another service type.
+	ServiceType string
+
+	// PlanName changes the plan, within the class of the instance. It is the
+	// kubernetes name of the plan when the class is referenced by its kubernetes
+	// name, otherwise the external name.
+	PlanName string
+
+	// Params are merged over the parameters of the instance.
+	Params interface{}
+
+	// Secrets maps secret names to the keys holding additional parameters.
+	Secrets map[string]string
+}
+
+// UpdateTemplatedInstance applies changes to the service type, plan and parameters of an instance,
+// retrying when the instance was modified concurrently. The controller then syncs the changes to
+// the service instance.
+func (sdk *SDK) UpdateTemplatedInstance(ns, name string, update TemplatedInstanceUpdate, retries int) (*templates.TemplatedInstance, error) {
+	result, err := sdk.retryTemplatedInstanceUpdate(ns, name, retries, func(inst *templates.TemplatedInstance) error {
+		return sdk.applyTemplatedInstanceUpdate(inst, update)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not update templated instance %s/%s (%s)", ns, name, err)
+	}
+	return result, nil
+}
+
+func (sdk *SDK) applyTemplatedInstanceUpdate(inst *templates.TemplatedInstance, update TemplatedInstanceUpdate) error {
+	if update.ServiceType != "" && update.ServiceType != inst.Spec.ServiceType {
+		if err := sdk.reresolveTemplatedInstance(inst, update.ServiceType); err != nil {
+			return err
+		}
+	}
+
+	if update.PlanName != "" {
+		// The plan is referenced the same way as the class, so that the plan
+		// reference never mixes kubernetes names with external names.
+		if inst.Spec.ClusterServiceClassName != "" {
+			inst.Spec.ClusterServicePlanName = update.PlanName
+			inst.Spec.ClusterServicePlanExternalName = ""
+		} else {
+			inst.Spec.ClusterServicePlanExternalName = update.PlanName
+			inst.Spec.ClusterServicePlanName = ""
+		}
+		if inst.Spec.ClusterServiceClassName != "" || inst.Spec.ClusterServiceClassExternalName != "" {
+			if _, err := sdk.ValidatePlanReference(inst.Spec.PlanReference); err != nil {
+				return err
+			}
+		}
+	}
+
+	if update.Params != nil {
+		params, err := builder.MergeParameters(inst.Spec.Parameters, svcat.BuildParameters(update.Params))
+		if err != nil {
+			return err
+		}
+		inst.Spec.Parameters = params
+	}
+
+	for _, src := range svcat.BuildParametersFrom(update.Secrets) {
+		if !hasParametersFromSource(inst.Spec.ParametersFrom, src) {
+			inst.Spec.ParametersFrom = append(inst.Spec.ParametersFrom, src)
+		}
+	}
+
+	return nil
+}
+
+// reresolveTemplatedInstance resolves the instance again with the templates of another service type.
+// What the templates of the current service type contributed is removed first, so that only the
+// parameters requested for the instance are kept. The service instance cannot move to another
+// class or provider, so those must not change.
+func (sdk *SDK) reresolveTemplatedInstance(inst *templates.TemplatedInstance, serviceType string) error {
+	request, err := sdk.unresolveInstance(inst)
+	if err != nil {
+		return fmt.Errorf("unable to remove the templates of service type %s (%s)", inst.Spec.ServiceType, err)
+	}
+	request.Spec.ServiceType = serviceType
+	request.Spec.PlanReference = servicecatalog.PlanReference{}
+	request.Spec.Provider = ""
+	request.Spec.Container = nil
+
+	res, err := sdk.ResolveInstance(request)
+	if err != nil {
+		return err
+	}
+	resolved := res.TemplatedInstance
+
+	if inst.Spec.Provider != "" && resolved.Spec.Provider != inst.Spec.Provider {
+		return fmt.Errorf("service type %s is provided by %s, but the instance is provided by %s",
+			serviceType, resolved.Spec.Provider, inst.Spec.Provider)
+	}
+	if !sameClass(inst.Spec.PlanReference, resolved.Spec.PlanReference) {
+		return fmt.Errorf("service type %s resolves to another class than the instance, the class of an instance cannot be changed",
+			serviceType)
+	}
+
+	inst.Spec.ServiceType = serviceType
+	inst.Spec.PlanReference = resolved.Spec.PlanReference
+	inst.Spec.Parameters = resolved.Spec.Parameters
+	inst.Spec.ParametersFrom = resolved.Spec.ParametersFrom
+	inst.Spec.Container = resolved.Spec.Container
+	return nil
+}
+
+// sameClass compares the classes of two plan references, by whichever name both of them use.
+func sameClass(a, b servicecatalog.PlanReference) bool {
+	if a.ClusterServiceClassExternalName != "" && b.ClusterServiceClassExternalName != "" {
+		return a.ClusterServiceClassExternalName == b.ClusterServiceClassExternalName
+	}
+	if a.ClusterServiceClassName != "" && b.ClusterServiceClassName != "" {
+		return a.ClusterServiceClassName == b.ClusterServiceClassName
+	}
+	return true
+}
+
+func hasParametersFromSource(sources []servicecatalog.ParametersFromSource, src servicecatalog.ParametersFromSource) bool {
+	for _, s := range sources {
+		if s.SecretKeyRef != nil && src.SecretKeyRef != nil && *s.SecretKeyRef == *src.SecretKeyRef {
+			return true
+		}
+	}
+	return false
+}
+
+// retryTemplatedInstanceUpdate gets the latest version of an instance, changes it and saves it,
+// trying again when the update conflicts with another change.
+func (sdk *SDK) retryTemplatedInstanceUpdate(ns, name string, retries int,
+	change func(inst *templates.TemplatedInstance) error) (*templates.TemplatedInstance, error) {
+
 	for j := 0; j < retries; j++ {
 		inst, err := sdk.RetrieveTemplatedInstance(ns, name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		inst.Spec.UpdateRequests = inst.Spec.UpdateRequests + 1
+		if err := change(inst); err != nil {
+			return nil, err
+		}
 
-		_, err = sdk.Templates().TemplatedInstances(ns).Update(inst)
+		result, err := sdk.Templates().TemplatedInstances(ns).Update(inst)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		// if we didn't get a conflict, no idea what happened
 		if !apierrors.IsConflict(err) {
-			return fmt.Errorf("could not touch templated instance (%s)", err)
+			return nil, err
 		}
 	}
 
 	// conflict after `retries` tries
-	return fmt.Errorf("could not update templated instance after %d tries", retries)
+	return nil, fmt.Errorf("conflicting changes after %d tries", retries)
 }
@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestProvisionAndBind_RollsBackInstance(t *testing.T) {
+	existing := &templates.TemplatedBinding{
+		ObjectMeta: meta.ObjectMeta{Name: "mydb", Namespace: "default"},
+	}
+	sdk, err := NewOffline(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = sdk.ProvisionAndBind("default", "mydb", "mysqldb", "", "", nil, nil, "")
+	if err == nil {
+		t.Fatal("expected the binding to conflict with the existing binding")
+	}
+
+	_, err = sdk.Templates().TemplatedInstances("default").Get("mydb", meta.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the instance to be rolled back, got %v", err)
+	}
+}
+
+func TestUpdateTemplatedInstance_MergesParameters(t *testing.T) {
+	existing := &templates.TemplatedInstance{
+		ObjectMeta: meta.ObjectMeta{Name: "mydb", Namespace: "default"},
+		Spec: templates.TemplatedInstanceSpec{
+			ServiceType: "mysqldb",
+			Parameters:  &runtime.RawExtension{Raw: []byte(`{"location":"eastus","sku":"basic"}`)},
+		},
+	}
+	sdk, err := NewOffline(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := TemplatedInstanceUpdate{
+		Params:  map[string]string{"sku": "standard"},
+		Secrets: map[string]string{"dbsecret": "password"},
+	}
+	tinst, err := sdk.UpdateTemplatedInstance("default", "mydb", update, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal(tinst.Spec.Parameters.Raw, &params); err != nil {
+		t.Fatal(err)
+	}
+	if params["location"] != "eastus" || params["sku"] != "standard" {
+		t.Fatalf("expected the parameters to be merged, got %v", params)
+	}
+	if len(tinst.Spec.ParametersFrom) != 1 || tinst.Spec.ParametersFrom[0].SecretKeyRef.Name != "dbsecret" {
+		t.Fatalf("expected the secret to be added, got %v", tinst.Spec.ParametersFrom)
+	}
+
+	// Adding the same secret again leaves a single reference
+	tinst, err = sdk.UpdateTemplatedInstance("default", "mydb", update, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tinst.Spec.ParametersFrom) != 1 {
+		t.Fatalf("expected the secret to be added once, got %v", tinst.Spec.ParametersFrom)
+	}
+}
+
+func TestUpdateTemplatedInstance_PlanByClassReference(t *testing.T) {
+	existing := &templates.TemplatedInstance{
+		ObjectMeta: meta.ObjectMeta{Name: "mydb", Namespace: "default"},
+		Spec: templates.TemplatedInstanceSpec{
+			ServiceType: "mysqldb",
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassName: "997b8372-8dac-40ac-ae65-758b4a5075a5",
+				ClusterServicePlanName:  "427559f1-bf2a-45d3-8844-32374a3e58aa",
+			},
+		},
+	}
+	sdk, err := NewOffline(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withCatalog(sdk, newTestCatalog())
+
+	_, err = sdk.UpdateTemplatedInstance("default", "mydb", TemplatedInstanceUpdate{PlanName: "b9c3e1f2-5f37-4c5e-9a0c-0c2f5b8a7d11"}, 3)
+	if err == nil {
+		t.Fatal("expected a plan of another class to be rejected")
+	}
+
+	tinst, err := sdk.UpdateTemplatedInstance("default", "mydb", TemplatedInstanceUpdate{PlanName: "8a1ad4b4-1b9c-4c4b-9dca-0b7d4d2ee6c4"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tinst.Spec.ClusterServicePlanName != "8a1ad4b4-1b9c-4c4b-9dca-0b7d4d2ee6c4" || tinst.Spec.ClusterServicePlanExternalName != "" {
+		t.Fatalf("expected the plan to be referenced by kubernetes name like the class, got %+v", tinst.Spec.PlanReference)
+	}
+}
+
+func TestUpdateTemplatedInstance_ServiceType(t *testing.T) {
+	mysql := newTestInstanceTemplate("default", "mysqldb", "mysqldb", "azure-mysql", "basic50", `{"location":"eastus","firewall":true}`)
+	mysql.Spec.ParametersFrom = []servicecatalog.ParametersFromSource{
+		{SecretKeyRef: &servicecatalog.SecretKeyReference{Name: "mysql-admin", Key: "password"}},
+	}
+	mysqlHA := newTestInstanceTemplate("default", "mysqldb-ha", "mysqldb-ha", "azure-mysql", "standard100", `{"location":"westus","replicas":2}`)
+	mysqlHA.Spec.ParametersFrom = []servicecatalog.ParametersFromSource{
+		{SecretKeyRef: &servicecatalog.SecretKeyReference{Name: "mysql-ha-admin", Key: "password"}},
+	}
+
+	// The instance was resolved and saved by the controller, with the database requested for it
+	existing := newTestTemplatedInstance("default", "mydb", "mysqldb", "", nil)
+	existing.Spec.PlanReference = mysql.Spec.PlanReference
+	existing.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"database":"wordpress","location":"eastus","firewall":true}`)}
+	existing.Spec.ParametersFrom = mysql.Spec.ParametersFrom
+
+	sdk, err := NewOffline(mysql, mysqlHA, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tinst, err := sdk.UpdateTemplatedInstance("default", "mydb", TemplatedInstanceUpdate{ServiceType: "mysqldb-ha"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tinst.Spec.ClusterServicePlanExternalName != "standard100" {
+		t.Fatalf("expected the plan of the new service type, got %+v", tinst.Spec.PlanReference)
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal(tinst.Spec.Parameters.Raw, &params); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"database": "wordpress", "location": "westus", "replicas": float64(2)}
+	if !reflect.DeepEqual(params, want) {
+		t.Fatalf("expected the parameters %v, got %v", want, params)
+	}
+	if !reflect.DeepEqual(tinst.Spec.ParametersFrom, mysqlHA.Spec.ParametersFrom) {
+		t.Fatalf("expected the secrets of the new service type, got %v", tinst.Spec.ParametersFrom)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"fmt"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// TemplatedInstanceHandler is called with each change to a watched instance,
+// and returns true to stop watching.
+type TemplatedInstanceHandler func(eventType watch.EventType, tinst *templates.TemplatedInstance) (bool, error)
+
+// TemplatedBindingHandler is called with each change to a watched binding,
+// and returns true to stop watching.
+type TemplatedBindingHandler func(eventType watch.EventType, tbnd *templates.TemplatedBinding) (bool, error)
+
+// WatchTemplatedInstances watches the changes made after a resource version to the
+// instances in a namespace, or in all namespaces when the namespace is empty, that match
+// the options. When the name is set, only that instance is watched. It returns when the
+// handler is done, fails, or the server closes the watch.
+func (sdk *SDK) WatchTemplatedInstances(ns, name, resourceVersion string, opts TemplatedListOptions, handle TemplatedInstanceHandler) error {
+	w, err := sdk.Templates().TemplatedInstances(ns).Watch(watchOptions(name, resourceVersion, opts))
+	if err != nil {
+		return fmt.Errorf("unable to watch templated instances in %s (%s)", ns, err)
+	}
+	defer w.Stop()
+
+	for event := range w.ResultChan() {
+		if event.Type == watch.Error {
+			return watchError(event)
+		}
+		tinst, ok := event.Object.(*templates.TemplatedInstance)
+		if !ok || (name != "" && tinst.Name != name) || !opts.matchesInstance(tinst) {
+			continue
+		}
+		if done, err := handle(event.Type, tinst); done || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WatchTemplatedBindings watches the changes made after a resource version to the
+// bindings in a namespace, or in all namespaces when the namespace is empty, that match
+// the options. When the name is set, only that binding is watched. It returns when the
+// handler is done, fails, or the server closes the watch.
+func (sdk *SDK) WatchTemplatedBindings(ns, name, resourceVersion string, opts TemplatedListOptions, handle TemplatedBindingHandler) error {
+	serviceTypes, err := sdk.instanceServiceTypes(ns, opts)
+	if err != nil {
+		return err
+	}
+
+	w, err := sdk.Templates().TemplatedBindings(ns).Watch(watchOptions(name, resourceVersion, opts))
+	if err != nil {
+		return fmt.Errorf("unable to watch bindings in %s (%s)", ns, err)
+	}
+	defer w.Stop()
+
+	for event := range w.ResultChan() {
+		if event.Type == watch.Error {
+			return watchError(event)
+		}
+		tbnd, ok := event.Object.(*templates.TemplatedBinding)
+		if !ok || (name != "" && tbnd.Name != name) || !opts.matchesBinding(tbnd, serviceTypes.lookup(sdk, tbnd)) {
+			continue
+		}
+		if done, err := handle(event.Type, tbnd); done || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func watchOptions(name, resourceVersion string, opts TemplatedListOptions) meta.ListOptions {
+	listOpts := meta.ListOptions{
+		LabelSelector:   opts.Selector,
+		ResourceVersion: resourceVersion,
+	}
+	if name != "" {
+		listOpts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
+	return listOpts
+}
+
+func watchError(event watch.Event) error {
+	if status, ok := event.Object.(*meta.Status); ok {
+		return fmt.Errorf("watch failed (%s)", apierrors.FromObject(status))
+	}
+	return fmt.Errorf("watch failed (%v)", event.Object)
+}
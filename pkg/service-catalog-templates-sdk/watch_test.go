@@ -0,0 +1,47 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package servicecatalogtempltesdk
+
+import (
+	"testing"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned/fake"
+)
+
+func TestWatchTemplatedInstances_Filters(t *testing.T) {
+	sdk, err := NewOffline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := watch.NewFake()
+	sdk.Client.(*fake.Clientset).PrependWatchReactor("templatedinstances", clienttesting.DefaultWatchReactor(w, nil))
+
+	go func() {
+		w.Add(newTestTemplatedInstance("default", "cache", "redis", svcat.ConditionUnknown, nil))
+		w.Add(newTestTemplatedInstance("default", "wordpress-db", "mysqldb", svcat.ConditionUnknown, nil))
+		w.Modify(newTestTemplatedInstance("default", "cache", "redis", svcat.ConditionTrue, nil))
+		w.Modify(newTestTemplatedInstance("default", "wordpress-db", "mysqldb", svcat.ConditionTrue, nil))
+	}()
+
+	var seen []watch.EventType
+	err = sdk.WatchTemplatedInstances("default", "", "1", TemplatedListOptions{ServiceType: "mysqldb"},
+		func(eventType watch.EventType, tinst *templates.TemplatedInstance) (bool, error) {
+			if tinst.Spec.ServiceType != "mysqldb" {
+				t.Fatalf("expected only mysqldb instances, got %s", tinst.Name)
+			}
+			seen = append(seen, eventType)
+			return eventType == watch.Modified, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 2 || seen[0] != watch.Added || seen[1] != watch.Modified {
+		t.Fatalf("expected the instance to be added and then modified, got %v", seen)
+	}
+}
@@ -14,9 +14,14 @@ import (
 
 func BuildServiceBinding(tbnd *templates.TemplatedBinding) *svcat.ServiceBinding {
 	return &svcat.ServiceBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: svcat.SchemeGroupVersion.String(),
+			Kind:       "ServiceBinding",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      tbnd.Name,
 			Namespace: tbnd.Namespace,
+			Labels:    BindingLabels(tbnd),
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(tbnd, templates.SchemeGroupVersion.WithKind(templates.BindingKind)),
 			},
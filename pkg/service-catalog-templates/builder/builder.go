@@ -6,6 +6,7 @@ package builder
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/peterbourgon/mergemap"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -44,3 +45,68 @@ func MergeParametersFromSource(instParams []svcat.ParametersFromSource, tmplPara
 
 	return instParams
 }
+
+// SubtractParameters removes the parameters that have the same value in the template,
+// which are what the template contributed when they were merged.
+func SubtractParameters(instParams *runtime.RawExtension, tmplParams *runtime.RawExtension) (*runtime.RawExtension, error) {
+	if instParams == nil || tmplParams == nil {
+		return instParams, nil
+	}
+
+	var instMap, tmplMap map[string]interface{}
+	if err := json.Unmarshal(instParams.Raw, &instMap); err != nil {
+		return nil, fmt.Errorf("could not read the instance parameters: %s", err)
+	}
+	if err := json.Unmarshal(tmplParams.Raw, &tmplMap); err != nil {
+		return nil, fmt.Errorf("could not read the template parameters: %s", err)
+	}
+
+	subtractMap(instMap, tmplMap)
+	if len(instMap) == 0 {
+		return nil, nil
+	}
+
+	result, err := json.Marshal(instMap)
+	if err != nil {
+		return nil, fmt.Errorf("could not subtract the template parameters: %s", err)
+	}
+	return &runtime.RawExtension{Raw: result}, nil
+}
+
+func subtractMap(inst map[string]interface{}, tmpl map[string]interface{}) {
+	for key, tmplValue := range tmpl {
+		instValue, ok := inst[key]
+		if !ok {
+			continue
+		}
+
+		instChild, instIsMap := instValue.(map[string]interface{})
+		tmplChild, tmplIsMap := tmplValue.(map[string]interface{})
+		if instIsMap && tmplIsMap {
+			subtractMap(instChild, tmplChild)
+			if len(instChild) == 0 {
+				delete(inst, key)
+			}
+		} else if reflect.DeepEqual(instValue, tmplValue) {
+			delete(inst, key)
+		}
+	}
+}
+
+// SubtractParametersFromSource removes the sources that the template contributed.
+func SubtractParametersFromSource(instParams []svcat.ParametersFromSource, tmplParams []svcat.ParametersFromSource) []svcat.ParametersFromSource {
+	var result []svcat.ParametersFromSource
+	for _, src := range instParams {
+		contributed := false
+		for _, tmplSrc := range tmplParams {
+			if reflect.DeepEqual(src, tmplSrc) {
+				contributed = true
+				break
+			}
+		}
+		if !contributed {
+			result = append(result, src)
+		}
+	}
+	return result
+}
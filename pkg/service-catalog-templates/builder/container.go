@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+// InstanceLabels are the labels applied to the resources managed for a templated instance.
+func InstanceLabels(tinst *templates.TemplatedInstance) map[string]string {
+	return map[string]string{
+		templates.LabelTemplatedInstance: tinst.Name,
+	}
+}
+
+// ManagedWorkloadSelector selects the workloads managed by the Templates controller.
+func ManagedWorkloadSelector() string {
+	return templates.LabelTemplatedInstance
+}
+
+// UsesContainerProvider determines if a templated instance is provided by a container
+// instead of service catalog.
+func UsesContainerProvider(tinst *templates.TemplatedInstance) bool {
+	return tinst.Spec.Provider == templates.ProviderContainer
+}
+
+// BuildContainerDeployment builds the deployment that runs the service for a
+// templated instance with the Container provider.
+func BuildContainerDeployment(tinst *templates.TemplatedInstance) *apps.Deployment {
+	labels := InstanceLabels(tinst)
+
+	template := tinst.Spec.Container.Template.DeepCopy()
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		template.Labels[k] = v
+	}
+
+	replicas := int32(1)
+	return &apps.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apps.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tinst.Name,
+			Namespace: tinst.Namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(tinst, templates.SchemeGroupVersion.WithKind(templates.InstanceKind)),
+			},
+		},
+		Spec: apps.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: *template,
+		},
+	}
+}
+
+// RefreshContainerDeployment pushes the rendered pod template back to a deployment.
+func RefreshContainerDeployment(desired, actual *apps.Deployment) *apps.Deployment {
+	actual.Spec.Template = desired.Spec.Template
+	return actual
+}
+
+// BuildContainerService builds the service in front of the deployment for a
+// templated instance with the Container provider.
+func BuildContainerService(tinst *templates.TemplatedInstance) *core.Service {
+	labels := InstanceLabels(tinst)
+
+	return &core.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: core.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tinst.Name,
+			Namespace: tinst.Namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(tinst, templates.SchemeGroupVersion.WithKind(templates.InstanceKind)),
+			},
+		},
+		Spec: core.ServiceSpec{
+			Selector: labels,
+			Ports:    tinst.Spec.Container.Ports,
+		},
+	}
+}
+
+// RefreshContainerService pushes the rendered ports and selector back to a service.
+func RefreshContainerService(desired, actual *core.Service) *core.Service {
+	actual.Spec.Ports = desired.Spec.Ports
+	actual.Spec.Selector = desired.Spec.Selector
+	return actual
+}
+
+// BuildContainerSecret builds the secret with the credentials of a templated
+// instance with the Container provider. It stands in for the secret that
+// service catalog would create for the binding, so the secret keys of the
+// binding are mapped the same way.
+func BuildContainerSecret(tinst *templates.TemplatedInstance, tbnd *templates.TemplatedBinding) *core.Secret {
+	return &core.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: core.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ShadowSecretName(tbnd.Spec.SecretName),
+			Namespace: tbnd.Namespace,
+			Labels:    BindingLabels(tbnd),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(tbnd, templates.SchemeGroupVersion.WithKind(templates.BindingKind)),
+			},
+		},
+		Type: core.SecretTypeOpaque,
+		Data: buildContainerCredentials(tinst),
+	}
+}
+
+// buildContainerCredentials expands the credentials of the container provider.
+// Without credentials, only the host and port of the service are provided.
+func buildContainerCredentials(tinst *templates.TemplatedInstance) map[string][]byte {
+	host := fmt.Sprintf("%s.%s.svc", tinst.Name, tinst.Namespace)
+	port := ""
+	if ports := tinst.Spec.Container.Ports; len(ports) > 0 {
+		port = strconv.Itoa(int(ports[0].Port))
+	}
+
+	credentials := tinst.Spec.Container.Credentials
+	if len(credentials) == 0 {
+		credentials = map[string]string{
+			"host": "$(host)",
+			"port": "$(port)",
+		}
+	}
+
+	replacer := strings.NewReplacer(
+		"$(host)", host,
+		"$(port)", port,
+		"$(instance)", tinst.Name,
+		"$(namespace)", tinst.Namespace,
+	)
+	data := make(map[string][]byte, len(credentials))
+	for k, v := range credentials {
+		data[k] = []byte(replacer.Replace(v))
+	}
+	return data
+}
+
+// MergeProvider overrides the provider and container with those of a more specific template, when it sets them.
+func MergeProvider(provider templates.Provider, container *templates.ContainerProvider,
+	tmplProvider templates.Provider, tmplContainer *templates.ContainerProvider) (templates.Provider, *templates.ContainerProvider) {
+
+	if tmplProvider != "" {
+		provider = tmplProvider
+	}
+	if tmplContainer != nil {
+		container = tmplContainer
+	}
+	return provider, container
+}
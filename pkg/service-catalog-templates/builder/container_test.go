@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package builder
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+func TestBuildContainerSecret(t *testing.T) {
+	tinst := &templates.TemplatedInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "testdb", Namespace: "dev"},
+		Spec: templates.TemplatedInstanceSpec{
+			Provider: templates.ProviderContainer,
+			Container: &templates.ContainerProvider{
+				Ports: []core.ServicePort{{Port: 3306}},
+				Credentials: map[string]string{
+					"uri":      "mysql://$(host):$(port)/$(instance)",
+					"username": "root",
+				},
+			},
+		},
+	}
+	tbnd := &templates.TemplatedBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "testdb", Namespace: "dev"},
+		Spec:       templates.TemplatedBindingSpec{SecretName: "testdb-creds"},
+	}
+
+	secret := BuildContainerSecret(tinst, tbnd)
+
+	if secret.Name != ShadowSecretName("testdb-creds") {
+		t.Fatalf("expected the service catalog secret name, got %q", secret.Name)
+	}
+	if got := string(secret.Data["uri"]); got != "mysql://testdb.dev.svc:3306/testdb" {
+		t.Fatalf("expected the uri to be expanded, got %q", got)
+	}
+	if got := string(secret.Data["username"]); got != "root" {
+		t.Fatalf("expected the username to be unchanged, got %q", got)
+	}
+
+	tinst.Spec.Container.Credentials = nil
+	secret = BuildContainerSecret(tinst, tbnd)
+	if got := string(secret.Data["host"]) + ":" + string(secret.Data["port"]); got != "testdb.dev.svc:3306" {
+		t.Fatalf("expected the default host and port credentials, got %q", got)
+	}
+}
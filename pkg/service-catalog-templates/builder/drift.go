@@ -0,0 +1,257 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	sdkerrors "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// immutableFields are rendered into the managed resources, but are not updated by
+// RefreshServiceInstance or RefreshServiceBinding. Drift in them is only reported.
+var immutableFields = map[string]bool{
+	"spec.instanceRef.name": true,
+	"spec.secretName":       true,
+}
+
+// DiffServiceInstance compares the fields of a service instance that are rendered
+// from a templated instance, returning the fields where the live instance differs.
+// Parameters that are not valid JSON cannot be compared, and are returned as an error.
+func DiffServiceInstance(desired, actual *svcat.ServiceInstance) ([]templates.FieldDrift, error) {
+	var drift []templates.FieldDrift
+
+	paramsDrift, err := diffParameters("spec.parameters", desired.Spec.Parameters, actual.Spec.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	drift = append(drift, diffPlanReference(desired.Spec.PlanReference, actual.Spec.PlanReference)...)
+	drift = append(drift, paramsDrift...)
+	drift = append(drift, diffValue("spec.parametersFrom", desired.Spec.ParametersFrom, actual.Spec.ParametersFrom)...)
+	drift = append(drift, diffValue("spec.updateRequests", desired.Spec.UpdateRequests, actual.Spec.UpdateRequests)...)
+
+	return drift, nil
+}
+
+// DiffServiceBinding compares the fields of a service binding that are rendered
+// from a templated binding, returning the fields where the live binding differs.
+// Parameters that are not valid JSON cannot be compared, and are returned as an error.
+func DiffServiceBinding(desired, actual *svcat.ServiceBinding) ([]templates.FieldDrift, error) {
+	var drift []templates.FieldDrift
+
+	paramsDrift, err := diffParameters("spec.parameters", desired.Spec.Parameters, actual.Spec.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	drift = append(drift, diffValue("spec.instanceRef.name", desired.Spec.ServiceInstanceRef.Name, actual.Spec.ServiceInstanceRef.Name)...)
+	drift = append(drift, diffValue("spec.secretName", desired.Spec.SecretName, actual.Spec.SecretName)...)
+	drift = append(drift, paramsDrift...)
+	drift = append(drift, diffValue("spec.parametersFrom", desired.Spec.ParametersFrom, actual.Spec.ParametersFrom)...)
+
+	return drift, nil
+}
+
+// DiffContainerDeployment compares the pod template of a deployment that is rendered
+// from a templated instance with the Container provider. Fields that the API server
+// defaults, and labels or annotations added to the live deployment, are not drift.
+func DiffContainerDeployment(desired, actual *apps.Deployment) []templates.FieldDrift {
+	var drift []templates.FieldDrift
+
+	drift = append(drift, diffDerivative("spec.template.metadata.labels", desired.Spec.Template.Labels, actual.Spec.Template.Labels)...)
+	drift = append(drift, diffDerivative("spec.template.metadata.annotations", desired.Spec.Template.Annotations, actual.Spec.Template.Annotations)...)
+	drift = append(drift, diffDerivative("spec.template.spec.initContainers", desired.Spec.Template.Spec.InitContainers, actual.Spec.Template.Spec.InitContainers)...)
+	drift = append(drift, diffDerivative("spec.template.spec.containers", desired.Spec.Template.Spec.Containers, actual.Spec.Template.Spec.Containers)...)
+	drift = append(drift, diffDerivative("spec.template.spec.volumes", desired.Spec.Template.Spec.Volumes, actual.Spec.Template.Spec.Volumes)...)
+
+	return drift
+}
+
+// DiffContainerService compares the ports and selector of a service that is rendered
+// from a templated instance with the Container provider.
+func DiffContainerService(desired, actual *core.Service) []templates.FieldDrift {
+	var drift []templates.FieldDrift
+
+	drift = append(drift, diffDerivative("spec.ports", desired.Spec.Ports, actual.Spec.Ports)...)
+	drift = append(drift, diffValue("spec.selector", desired.Spec.Selector, actual.Spec.Selector)...)
+
+	return drift
+}
+
+// CorrectableDrift returns the drift that is pushed back to the managed resource
+// when it is refreshed, leaving out the fields that cannot be updated.
+func CorrectableDrift(drift []templates.FieldDrift) []templates.FieldDrift {
+	var correctable []templates.FieldDrift
+	for _, d := range drift {
+		if !immutableFields[d.Field] {
+			correctable = append(correctable, d)
+		}
+	}
+	return correctable
+}
+
+// immutableDrift returns the drift that cannot be corrected by refreshing the managed resource.
+func immutableDrift(drift []templates.FieldDrift) []templates.FieldDrift {
+	var immutable []templates.FieldDrift
+	for _, d := range drift {
+		if immutableFields[d.Field] {
+			immutable = append(immutable, d)
+		}
+	}
+	return immutable
+}
+
+// ShouldCorrectDrift determines if drift should be pushed back to the managed resource.
+func ShouldCorrectDrift(policy templates.DriftPolicy) bool {
+	return policy != templates.DriftPolicyReport
+}
+
+// FormatDrift summarizes drifted fields for use in a condition message.
+func FormatDrift(drift []templates.FieldDrift) string {
+	fields := make([]string, 0, len(drift))
+	for _, d := range drift {
+		fields = append(fields, fmt.Sprintf("%s (expected %s, got %s)", d.Field, formatDriftValue(d.Expected), formatDriftValue(d.Actual)))
+	}
+	return strings.Join(fields, "; ")
+}
+
+func formatDriftValue(value string) string {
+	if value == "" {
+		return "<unset>"
+	}
+	return value
+}
+
+func diffPlanReference(desired, actual svcat.PlanReference) []templates.FieldDrift {
+	var drift []templates.FieldDrift
+	drift = append(drift, diffValue("spec.clusterServiceClassExternalName", desired.ClusterServiceClassExternalName, actual.ClusterServiceClassExternalName)...)
+	drift = append(drift, diffValue("spec.clusterServiceClassName", desired.ClusterServiceClassName, actual.ClusterServiceClassName)...)
+	drift = append(drift, diffValue("spec.clusterServicePlanExternalName", desired.ClusterServicePlanExternalName, actual.ClusterServicePlanExternalName)...)
+	drift = append(drift, diffValue("spec.clusterServicePlanName", desired.ClusterServicePlanName, actual.ClusterServicePlanName)...)
+	return drift
+}
+
+// diffParameters walks the parameter objects so that the drift is reported
+// for each parameter rather than for the parameters as a whole.
+func diffParameters(field string, desired, actual *runtime.RawExtension) ([]templates.FieldDrift, error) {
+	desiredValues, err := unmarshalParameters(desired)
+	if err != nil {
+		return nil, sdkerrors.NewInvalidParameters("unable to compare the rendered %s (%s)", field, err)
+	}
+	actualValues, err := unmarshalParameters(actual)
+	if err != nil {
+		return nil, sdkerrors.NewInvalidParameters("unable to compare the live %s (%s)", field, err)
+	}
+	return diffMaps(field, desiredValues, actualValues), nil
+}
+
+func diffMaps(field string, desired, actual map[string]interface{}) []templates.FieldDrift {
+	keys := make(map[string]struct{}, len(desired)+len(actual))
+	for k := range desired {
+		keys[k] = struct{}{}
+	}
+	for k := range actual {
+		keys[k] = struct{}{}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	var drift []templates.FieldDrift
+	for _, k := range sortedKeys {
+		keyField := field + "." + k
+		desiredValue, desiredOk := desired[k]
+		actualValue, actualOk := actual[k]
+
+		desiredMap, desiredIsMap := desiredValue.(map[string]interface{})
+		actualMap, actualIsMap := actualValue.(map[string]interface{})
+		if desiredIsMap && actualIsMap {
+			drift = append(drift, diffMaps(keyField, desiredMap, actualMap)...)
+			continue
+		}
+
+		if desiredOk && actualOk && reflect.DeepEqual(desiredValue, actualValue) {
+			continue
+		}
+		drift = append(drift, templates.FieldDrift{
+			Field:    keyField,
+			Expected: encodeDriftValue(desiredValue, desiredOk),
+			Actual:   encodeDriftValue(actualValue, actualOk),
+		})
+	}
+	return drift
+}
+
+// diffDerivative compares a rendered value with the live value, ignoring the
+// fields that are not set in the rendered value.
+func diffDerivative(field string, desired, actual interface{}) []templates.FieldDrift {
+	if equality.Semantic.DeepDerivative(desired, actual) {
+		return nil
+	}
+	return []templates.FieldDrift{{
+		Field:    field,
+		Expected: encodeDriftValue(desired, !isEmptyValue(desired)),
+		Actual:   encodeDriftValue(actual, !isEmptyValue(actual)),
+	}}
+}
+
+func diffValue(field string, desired, actual interface{}) []templates.FieldDrift {
+	if isEmptyValue(desired) && isEmptyValue(actual) {
+		return nil
+	}
+	if reflect.DeepEqual(desired, actual) {
+		return nil
+	}
+	return []templates.FieldDrift{{
+		Field:    field,
+		Expected: encodeDriftValue(desired, !isEmptyValue(desired)),
+		Actual:   encodeDriftValue(actual, !isEmptyValue(actual)),
+	}}
+}
+
+func isEmptyValue(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return reflect.DeepEqual(value, reflect.Zero(v.Type()).Interface())
+}
+
+func encodeDriftValue(value interface{}, ok bool) string {
+	if !ok {
+		return ""
+	}
+	result, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(result)
+}
+
+func unmarshalParameters(params *runtime.RawExtension) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if params == nil || len(params.Raw) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal(params.Raw, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
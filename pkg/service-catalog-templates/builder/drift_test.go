@@ -0,0 +1,162 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package builder
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	sdkerrors "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestDiffServiceInstance(t *testing.T) {
+	desired := &svcat.ServiceInstance{
+		Spec: svcat.ServiceInstanceSpec{
+			PlanReference: svcat.PlanReference{
+				ClusterServiceClassExternalName: "azure-mysql",
+				ClusterServicePlanExternalName:  "basic50",
+			},
+			Parameters: &runtime.RawExtension{Raw: []byte(`{"location":"eastus","firewall":{"start":"0.0.0.0","end":"255.255.255.255"}}`)},
+		},
+	}
+	actual := &svcat.ServiceInstance{
+		Spec: svcat.ServiceInstanceSpec{
+			PlanReference: svcat.PlanReference{
+				ClusterServiceClassExternalName: "azure-mysql",
+				ClusterServicePlanExternalName:  "standard100",
+			},
+			Parameters: &runtime.RawExtension{Raw: []byte(`{"firewall":{"end":"255.255.255.255","start":"10.0.0.0"},"location":"eastus","sku":"B"}`)},
+		},
+	}
+
+	drift, err := DiffServiceInstance(desired, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantFields := []string{
+		"spec.clusterServicePlanExternalName",
+		"spec.parameters.firewall.start",
+		"spec.parameters.sku",
+	}
+	if len(drift) != len(wantFields) {
+		t.Fatalf("expected %d drifted fields, got %#v", len(wantFields), drift)
+	}
+	for i, field := range wantFields {
+		if drift[i].Field != field {
+			t.Fatalf("expected drift[%d] to be %q got %q", i, field, drift[i].Field)
+		}
+	}
+	if drift[2].Expected != "" || drift[2].Actual != `"B"` {
+		t.Fatalf("expected an unset parameter to be reported as empty, got %#v", drift[2])
+	}
+}
+
+func TestDiffServiceInstance_NoDrift(t *testing.T) {
+	desired := &svcat.ServiceInstance{
+		Spec: svcat.ServiceInstanceSpec{
+			Parameters: &runtime.RawExtension{Raw: []byte(`{}`)},
+		},
+	}
+	actual := &svcat.ServiceInstance{}
+
+	drift, err := DiffServiceInstance(desired, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drift) != 0 {
+		t.Fatalf("expected empty and unset parameters to be equivalent, got %#v", drift)
+	}
+}
+
+func TestDiffServiceInstance_InvalidParameters(t *testing.T) {
+	desired := &svcat.ServiceInstance{}
+	actual := &svcat.ServiceInstance{
+		Spec: svcat.ServiceInstanceSpec{
+			Parameters: &runtime.RawExtension{Raw: []byte(`{"location":`)},
+		},
+	}
+
+	_, err := DiffServiceInstance(desired, actual)
+	if e := sdkerrors.Classify(err); e == nil || e.Reason != sdkerrors.ReasonInvalidParameters {
+		t.Fatalf("expected an invalid parameters error, got %v", err)
+	}
+}
+
+func TestSetDriftStatus_ImmutableBindingFields(t *testing.T) {
+	desired := &svcat.ServiceBinding{
+		Spec: svcat.ServiceBindingSpec{
+			ServiceInstanceRef: svcat.LocalObjectReference{Name: "mysql"},
+			SecretName:         "mysql-shadow",
+			Parameters:         &runtime.RawExtension{Raw: []byte(`{"role":"reader"}`)},
+		},
+	}
+	actual := &svcat.ServiceBinding{
+		Spec: svcat.ServiceBindingSpec{
+			ServiceInstanceRef: svcat.LocalObjectReference{Name: "mysql"},
+			SecretName:         "other",
+			Parameters:         &runtime.RawExtension{Raw: []byte(`{"role":"admin"}`)},
+		},
+	}
+
+	drift, err := DiffServiceBinding(desired, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drift) != 2 {
+		t.Fatalf("expected 2 drifted fields, got %#v", drift)
+	}
+	if correctable := CorrectableDrift(drift); len(correctable) != 1 || correctable[0].Field != "spec.parameters.role" {
+		t.Fatalf("expected only the parameters to be correctable, got %#v", correctable)
+	}
+
+	conditions, remaining := SetDriftStatus(nil, drift, templates.DriftPolicyCorrect)
+	c := GetCondition(conditions, templates.TemplatedConditionDrifted)
+	if c == nil || c.Status != svcat.ConditionTrue || c.Reason != ReasonDriftDetected {
+		t.Fatalf("expected the drift to be reported as detected, got %#v", c)
+	}
+	if len(remaining) != 1 || remaining[0].Field != "spec.secretName" {
+		t.Fatalf("expected the secret name to remain drifted, got %#v", remaining)
+	}
+}
+
+func TestDiffContainerDeployment(t *testing.T) {
+	tinst := &templates.TemplatedInstance{
+		Spec: templates.TemplatedInstanceSpec{
+			Container: &templates.ContainerProvider{
+				Template: core.PodTemplateSpec{
+					Spec: core.PodSpec{
+						Containers: []core.Container{{Name: "mysql", Image: "mysql:5.7"}},
+					},
+				},
+			},
+		},
+	}
+	desired := BuildContainerDeployment(tinst)
+
+	// The API server defaults fields that the template does not set
+	actual := desired.DeepCopy()
+	actual.Spec.Template.Spec.Containers[0].ImagePullPolicy = core.PullIfNotPresent
+	actual.Spec.Template.Spec.RestartPolicy = core.RestartPolicyAlways
+	if drift := DiffContainerDeployment(desired, actual); len(drift) != 0 {
+		t.Fatalf("expected defaulted fields to be ignored, got %#v", drift)
+	}
+
+	tinst.Spec.Container.Template.Spec.Containers[0].Image = "mysql:8.0"
+	desired = BuildContainerDeployment(tinst)
+	drift := DiffContainerDeployment(desired, actual)
+	if len(drift) != 1 || drift[0].Field != "spec.template.spec.containers" {
+		t.Fatalf("expected the containers to drift, got %#v", drift)
+	}
+
+	refreshed := RefreshContainerDeployment(desired, actual)
+	if refreshed.Spec.Template.Spec.Containers[0].Image != "mysql:8.0" {
+		t.Fatalf("expected the image to be updated, got %#v", refreshed.Spec.Template.Spec.Containers[0])
+	}
+}
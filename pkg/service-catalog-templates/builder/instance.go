@@ -5,6 +5,7 @@ package builder
 
 import (
 	"errors"
+	"reflect"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -20,6 +21,10 @@ func BuildServiceInstance(instance *templates.TemplatedInstance) (*svcat.Service
 	}
 
 	return &svcat.ServiceInstance{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: svcat.SchemeGroupVersion.String(),
+			Kind:       "ServiceInstance",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      instance.Name,
 			Namespace: instance.Namespace,
@@ -61,6 +66,46 @@ func ApplyInstanceTemplate(instance *templates.TemplatedInstance, template templ
 
 	instance.Spec.ParametersFrom = MergeParametersFromSource(instance.Spec.ParametersFrom, template.GetParametersFrom())
 
+	if instance.Spec.Provider == "" {
+		instance.Spec.Provider = template.GetProvider()
+	}
+	if instance.Spec.Provider == "" {
+		instance.Spec.Provider = templates.ProviderServiceCatalog
+	}
+	if instance.Spec.Container == nil && template.GetContainer() != nil {
+		instance.Spec.Container = template.GetContainer().DeepCopy()
+	}
+
+	return instance, nil
+}
+
+// UnapplyInstanceTemplate removes what a template contributed to a resolved instance,
+// so that the instance can be resolved again with other templates. The fields that
+// have the value of the template are cleared, the rest were requested for the instance.
+func UnapplyInstanceTemplate(instance *templates.TemplatedInstance, template templates.InstanceTemplateInterface) (*templates.TemplatedInstance, error) {
+	if reflect.DeepEqual(instance.Spec.PlanReference, template.GetPlanReference()) {
+		instance.Spec.PlanReference = svcat.PlanReference{}
+	}
+
+	var err error
+	instance.Spec.Parameters, err = SubtractParameters(instance.Spec.Parameters, template.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	instance.Spec.ParametersFrom = SubtractParametersFromSource(instance.Spec.ParametersFrom, template.GetParametersFrom())
+
+	provider := template.GetProvider()
+	if provider == "" {
+		provider = templates.ProviderServiceCatalog
+	}
+	if instance.Spec.Provider == provider {
+		instance.Spec.Provider = ""
+	}
+	if template.GetContainer() != nil && reflect.DeepEqual(instance.Spec.Container, template.GetContainer()) {
+		instance.Spec.Container = nil
+	}
+
 	return instance, nil
 }
 
@@ -23,6 +23,7 @@ func BuildBoundSecret(secret *core.Secret, tbnd *templates.TemplatedBinding) (*c
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      BoundSecretName(secret.Name),
 			Namespace: secret.Namespace,
+			Labels:    BindingLabels(tbnd),
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(secret, core.SchemeGroupVersion.WithKind("Secret")),
 			},
@@ -46,6 +47,34 @@ func RefreshSecret(svcSecret *core.Secret, tbnd *templates.TemplatedBinding, sec
 	return secret, true
 }
 
+// BindingLabels are the labels applied to the resources managed for a templated binding.
+func BindingLabels(tbnd *templates.TemplatedBinding) map[string]string {
+	return map[string]string{
+		templates.LabelTemplatedBinding: tbnd.Name,
+	}
+}
+
+// ManagedSecretSelector selects the secrets managed by the Templates controller.
+func ManagedSecretSelector() string {
+	return templates.LabelTemplatedBinding
+}
+
+// LabelSecret applies the templated binding labels to a secret, returning
+// false when the secret is already labeled.
+func LabelSecret(secret *core.Secret, tbnd *templates.TemplatedBinding) bool {
+	if secret.Labels[templates.LabelTemplatedBinding] == tbnd.Name {
+		return false
+	}
+
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	for k, v := range BindingLabels(tbnd) {
+		secret.Labels[k] = v
+	}
+	return true
+}
+
 func ShadowSecretName(name string) string {
 	return name + SecretSuffix
 }
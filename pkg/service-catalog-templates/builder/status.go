@@ -0,0 +1,229 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package builder
+
+import (
+	"fmt"
+
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	sdkerrors "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// GetCondition returns the condition of the specified type, or nil when it has not been set.
+func GetCondition(conditions []templates.TemplatedCondition, conditionType templates.TemplatedConditionType) *templates.TemplatedCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates a condition. The transition time is only
+// changed when the status of the condition changes.
+func SetCondition(conditions []templates.TemplatedCondition, conditionType templates.TemplatedConditionType,
+	status svcat.ConditionStatus, reason, message string) []templates.TemplatedCondition {
+
+	if existing := GetCondition(conditions, conditionType); existing != nil {
+		if existing.Status != status {
+			existing.LastTransitionTime = metav1.Now()
+		}
+		existing.Status = status
+		existing.Reason = reason
+		existing.Message = message
+		return conditions
+	}
+
+	return append(conditions, templates.TemplatedCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+const (
+	// ReasonInSync is the Drifted condition reason when the managed resource matches the rendered spec.
+	ReasonInSync = "InSync"
+	// ReasonDriftDetected is the Drifted condition reason when drift was found and left in place.
+	ReasonDriftDetected = "DriftDetected"
+	// ReasonDriftCorrected is the Drifted condition reason when drift was found and corrected.
+	ReasonDriftCorrected = "DriftCorrected"
+	// ReasonManagedResourceDeleted is the condition reason when the managed resource was deleted out-of-band and not recreated.
+	ReasonManagedResourceDeleted = "ManagedResourceDeleted"
+	// ReasonPending is the Ready condition reason when the managed resource has not reported its readiness yet.
+	ReasonPending = "Pending"
+	// ReasonResolved is the Resolved condition reason when the templates were resolved.
+	ReasonResolved = "Resolved"
+	// ReasonSynced is the Synced condition reason when the managed resource was synchronized.
+	ReasonSynced = "Synced"
+	// ReasonDeploymentAvailable is the Ready condition reason when the deployment of a container provided instance is available.
+	ReasonDeploymentAvailable = "DeploymentAvailable"
+	// ReasonDeploymentUnavailable is the Ready condition reason when the deployment of a container provided instance is not available yet.
+	ReasonDeploymentUnavailable = "DeploymentUnavailable"
+	// ReasonCredentialsInjected is the Ready condition reason when the credentials of a container provided instance were written for a binding.
+	ReasonCredentialsInjected = "CredentialsInjected"
+)
+
+// SetDriftStatus records the outcome of a drift check on a templated resource's status,
+// returning the updated conditions and the drift that remains on the managed resource.
+// Drift in fields that cannot be updated always remains, whatever the policy.
+func SetDriftStatus(conditions []templates.TemplatedCondition, drift []templates.FieldDrift,
+	policy templates.DriftPolicy) ([]templates.TemplatedCondition, []templates.FieldDrift) {
+
+	if len(drift) == 0 {
+		return SetCondition(conditions, templates.TemplatedConditionDrifted, svcat.ConditionFalse,
+			ReasonInSync, "The managed resource matches the rendered spec"), nil
+	}
+
+	if ShouldCorrectDrift(policy) {
+		remaining := immutableDrift(drift)
+		if len(remaining) == 0 {
+			return SetCondition(conditions, templates.TemplatedConditionDrifted, svcat.ConditionFalse,
+				ReasonDriftCorrected, "Corrected drift in "+FormatDrift(drift)), nil
+		}
+		return SetCondition(conditions, templates.TemplatedConditionDrifted, svcat.ConditionTrue,
+			ReasonDriftDetected, "Detected drift that cannot be corrected in "+FormatDrift(remaining)), remaining
+	}
+
+	return SetCondition(conditions, templates.TemplatedConditionDrifted, svcat.ConditionTrue,
+		ReasonDriftDetected, "Detected drift in "+FormatDrift(drift)), drift
+}
+
+// SetInstanceReadyStatus copies the Ready condition of a service instance onto a templated instance's conditions.
+// A service instance that the broker failed to provision is reported as a broker failure.
+func SetInstanceReadyStatus(conditions []templates.TemplatedCondition, inst *svcat.ServiceInstance) []templates.TemplatedCondition {
+	if failed, message := GetInstanceFailure(inst); failed {
+		return SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionFalse, string(sdkerrors.ReasonBrokerFailure), message)
+	}
+	for _, c := range inst.Status.Conditions {
+		if c.Type == svcat.ServiceInstanceConditionReady {
+			return SetCondition(conditions, templates.TemplatedConditionReady, c.Status, c.Reason, c.Message)
+		}
+	}
+	return SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionUnknown,
+		ReasonPending, "The service instance has not reported its status")
+}
+
+// SetBindingReadyStatus copies the Ready condition of a service binding onto a templated binding's conditions.
+// A service binding that the broker failed to bind is reported as a broker failure.
+func SetBindingReadyStatus(conditions []templates.TemplatedCondition, bnd *svcat.ServiceBinding) []templates.TemplatedCondition {
+	if failed, message := GetBindingFailure(bnd); failed {
+		return SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionFalse, string(sdkerrors.ReasonBrokerFailure), message)
+	}
+	for _, c := range bnd.Status.Conditions {
+		if c.Type == svcat.ServiceBindingConditionReady {
+			return SetCondition(conditions, templates.TemplatedConditionReady, c.Status, c.Reason, c.Message)
+		}
+	}
+	return SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionUnknown,
+		ReasonPending, "The service binding has not reported its status")
+}
+
+// SetDeploymentReadyStatus reports a templated instance with the Container
+// provider as ready once its deployment has an available replica.
+func SetDeploymentReadyStatus(conditions []templates.TemplatedCondition, deployment *apps.Deployment) []templates.TemplatedCondition {
+	if deployment.Status.AvailableReplicas > 0 {
+		return SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionTrue,
+			ReasonDeploymentAvailable, "The deployment is available")
+	}
+	return SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionFalse,
+		ReasonDeploymentUnavailable, "Waiting for the deployment to become available")
+}
+
+// SetCredentialsReadyStatus reports a templated binding to a container provided instance as ready.
+func SetCredentialsReadyStatus(conditions []templates.TemplatedCondition) []templates.TemplatedCondition {
+	return SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionTrue,
+		ReasonCredentialsInjected, "The credentials of the container were written to the secret")
+}
+
+// SetDeletedStatus records that the managed resource, e.g. a service instance,
+// was deleted out-of-band and was not recreated because of the drift policy.
+func SetDeletedStatus(conditions []templates.TemplatedCondition, resource string) []templates.TemplatedCondition {
+	message := fmt.Sprintf("The %s was deleted and the drift policy does not allow it to be recreated", resource)
+	conditions = SetCondition(conditions, templates.TemplatedConditionReady, svcat.ConditionFalse, ReasonManagedResourceDeleted, message)
+	return SetCondition(conditions, templates.TemplatedConditionDrifted, svcat.ConditionTrue, ReasonManagedResourceDeleted, message)
+}
+
+// WasProvisioned determines if the managed resource of a templated resource has been created before.
+func WasProvisioned(conditions []templates.TemplatedCondition) bool {
+	return GetCondition(conditions, templates.TemplatedConditionReady) != nil
+}
+
+// GetInstanceFailure returns the message of a service instance's Failed condition, when the broker failed to provision it.
+func GetInstanceFailure(inst *svcat.ServiceInstance) (bool, string) {
+	for _, c := range inst.Status.Conditions {
+		if c.Type == svcat.ServiceInstanceConditionFailed && c.Status == svcat.ConditionTrue {
+			return true, c.Message
+		}
+	}
+	return false, ""
+}
+
+// GetBindingFailure returns the message of a service binding's Failed condition, when the broker failed to bind it.
+func GetBindingFailure(bnd *svcat.ServiceBinding) (bool, string) {
+	for _, c := range bnd.Status.Conditions {
+		if c.Type == svcat.ServiceBindingConditionFailed && c.Status == svcat.ConditionTrue {
+			return true, c.Message
+		}
+	}
+	return false, ""
+}
+
+// SetSyncedStatus records a successful synchronization, clearing any errors
+// reported by the Resolved and Synced conditions.
+func SetSyncedStatus(conditions []templates.TemplatedCondition) []templates.TemplatedCondition {
+	conditions = SetCondition(conditions, templates.TemplatedConditionResolved, svcat.ConditionTrue,
+		ReasonResolved, "The templates were resolved")
+	return SetCondition(conditions, templates.TemplatedConditionSynced, svcat.ConditionTrue,
+		ReasonSynced, "The managed resource is synchronized")
+}
+
+// SetErrorStatus records a synchronization error on the condition for its class.
+// It returns false when the condition already reports the error.
+func SetErrorStatus(conditions []templates.TemplatedCondition, err *sdkerrors.Error) ([]templates.TemplatedCondition, bool) {
+	existing := GetCondition(conditions, err.Condition)
+	if existing != nil && existing.Status == svcat.ConditionFalse && existing.Reason == string(err.Reason) && existing.Message == err.Message {
+		return conditions, false
+	}
+	return SetCondition(conditions, err.Condition, svcat.ConditionFalse, string(err.Reason), err.Message), true
+}
+
+// Summaries of the conditions of a templated resource, as reported by GetStatus.
+const (
+	StatusReady   = "Ready"
+	StatusPending = "Pending"
+	StatusFailed  = "Failed"
+)
+
+// GetStatus summarizes the conditions of a templated resource. It is Failed when the
+// templates could not be applied, the managed resource could not be synchronized or
+// the broker failed, Ready once its managed resource is ready, and Pending otherwise.
+// Transient errors are retried, so they leave the resource Pending.
+func GetStatus(conditions []templates.TemplatedCondition) string {
+	for _, conditionType := range []templates.TemplatedConditionType{templates.TemplatedConditionResolved, templates.TemplatedConditionSynced} {
+		c := GetCondition(conditions, conditionType)
+		if c != nil && c.Status == svcat.ConditionFalse && c.Reason != string(sdkerrors.ReasonTransient) {
+			return StatusFailed
+		}
+	}
+
+	c := GetCondition(conditions, templates.TemplatedConditionReady)
+	switch {
+	case c == nil:
+		return StatusPending
+	case c.Status == svcat.ConditionTrue:
+		return StatusReady
+	case c.Status == svcat.ConditionFalse &&
+		(c.Reason == string(sdkerrors.ReasonBrokerFailure) || c.Reason == ReasonManagedResourceDeleted):
+		return StatusFailed
+	}
+	return StatusPending
+}
@@ -5,8 +5,10 @@ package servicecatalogtemplates
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/Azure/service-catalog-templates/pkg/kubernetes/core-sdk"
+	"github.com/Azure/service-catalog-templates/pkg/metrics"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-sdk"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
 	sdkerrors "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
@@ -27,11 +29,12 @@ import (
 const (
 	// MessageResourceExists is the message used for Events when a resource
 	// fails to sync due to a Deployment already existing
+	//
+	// Deprecated: use errors.ErrorUnmanagedResource in pkg/service-catalog-templates-sdk/errors.
 	MessageResourceExists = "Resource %q already exists and is not managed by the Templates controller"
 )
 
 type Synchronizer struct {
-	resolver    *resolver
 	coreSDK     *coresdk.SDK
 	templateSDK *servicecatalogtempltesdk.SDK
 	svcatSDK    *servicecatalogsdk.SDK
@@ -42,16 +45,17 @@ func NewSynchronizer(coreSDK *coresdk.SDK, templateSDK *servicecatalogtempltesdk
 		coreSDK:     coreSDK,
 		templateSDK: templateSDK,
 		svcatSDK:    svcatSDK,
-		resolver:    newResolver(templateSDK, svcatSDK),
 	}
 }
 
-// IsManaged determines if a resource is managed by a shadow resource.
-func (s *Synchronizer) IsManaged(object meta.Object) bool {
+// GetManagingResource returns the owner reference of the shadow resource that
+// manages a resource, following a secret back through its service binding.
+// When the resource is not managed by a shadow resource, false is returned.
+func (s *Synchronizer) GetManagingResource(object meta.Object) (*meta.OwnerReference, bool) {
 	owner := meta.GetControllerOf(object)
 	if owner == nil {
 		// Ignore unmanaged service catalog resources
-		return false
+		return nil, false
 	}
 
 	// Try to retrieve the resource that is shadowing the service catalog resource
@@ -60,29 +64,29 @@ func (s *Synchronizer) IsManaged(object meta.Object) bool {
 		_, err := s.templateSDK.GetBindingFromCache(object.GetNamespace(), owner.Name)
 		if err != nil {
 			glog.V(4).Infof("ignoring orphaned object '%s' of %s '%s'", object.GetSelfLink(), owner.Kind, owner.Name)
-			return false
+			return nil, false
 		}
-		return true
+		return owner, true
 	case templates.InstanceKind:
 		_, err := s.templateSDK.GetInstanceFromCache(object.GetNamespace(), owner.Name)
 		if err != nil {
 			glog.V(4).Infof("ignoring orphaned object '%s' of %s '%s'", object.GetSelfLink(), owner.Kind, owner.Name)
-			return false
+			return nil, false
 		}
-		return true
+		return owner, true
 	case "ServiceBinding":
 		// Lookup the binding that owns the resource
 		svcBnd, err := s.svcatSDK.GetBindingFromCache(object.GetNamespace(), owner.Name)
 		if err != nil {
 			glog.V(4).Infof("ignoring orphaned object '%s' of %s '%s'", object.GetSelfLink(), owner.Kind, owner.Name)
-			return false
+			return nil, false
 		}
 
 		// The binding must be owned by the templates controller
-		return s.IsManaged(svcBnd)
+		return s.GetManagingResource(svcBnd)
 	}
 
-	return false
+	return nil, false
 }
 
 // SynchronizeInstance accepts an instance key (namespace/name)
@@ -91,6 +95,14 @@ func (s *Synchronizer) IsManaged(object meta.Object) bool {
 // * resource - The resource.
 // * error - Fatal synchronization error.
 func (s *Synchronizer) SynchronizeInstance(key string) (bool, runtime.Object, error) {
+	ok, obj, err := s.synchronizeInstance(key)
+	if tinst, isInstance := obj.(*templates.TemplatedInstance); err != nil && isInstance && !sdkerrors.IsWaiting(err) {
+		s.updateInstanceErrorStatus(tinst, err)
+	}
+	return ok, obj, err
+}
+
+func (s *Synchronizer) synchronizeInstance(key string) (bool, runtime.Object, error) {
 	//
 	// Get shadow instance
 	//
@@ -120,6 +132,11 @@ func (s *Synchronizer) SynchronizeInstance(key string) (bool, runtime.Object, er
 		return false, nil, nil
 	}
 
+	// Instances provided by a container are deployed instead of provisioned
+	if builder.UsesContainerProvider(tinst) {
+		return s.synchronizeContainerInstance(tinst)
+	}
+
 	//
 	// Sync shadow to service catalog instance
 	//
@@ -127,31 +144,32 @@ func (s *Synchronizer) SynchronizeInstance(key string) (bool, runtime.Object, er
 	// Get the corresponding service instance from the service catalog
 	inst, err := s.templateSDK.GetManagedServiceInstance(tinst)
 	if sdkerrors.IsUnmanagedResource(err) {
-		msg := fmt.Sprintf(MessageResourceExists, inst.Name)
-		return false, tinst, fmt.Errorf(msg)
+		return false, tinst, err
 	} else if apierrors.IsNotFound(err) {
-		template, err := s.resolver.ResolveInstanceTemplate(tinst)
-		if err != nil {
-			// TODO: Update status to unresolvable
-			return false, tinst, err
+		// The service instance existed before, so it was deleted out-of-band.
+		// Only recreate it when the drift policy allows us to correct drift.
+		if builder.WasProvisioned(tinst.Status.Conditions) {
+			if !builder.ShouldCorrectDrift(tinst.Spec.DriftPolicy) {
+				glog.V(4).Infof("Service instance for %s was deleted, not recreating it because of the drift policy", key)
+				return false, tinst, s.updateInstanceDeletedStatus(tinst, "service instance")
+			}
+			glog.V(4).Infof("Service instance for %s was deleted, recreating it", key)
 		}
 
-		// Apply changes from the template to the instance
-		tinst, err = builder.ApplyInstanceTemplate(tinst, template)
-		if err != nil {
-			return false, tinst, err
-		}
-		tinst, err = s.templateSDK.Templates().TemplatedInstances(tinst.Namespace).Update(tinst)
+		// Apply changes from the template to the instance, and convert the
+		// templated resource into a service catalog resource
+		var resolution *servicecatalogtempltesdk.InstanceResolution
+		tinst, resolution, err = s.resolveInstance(tinst)
 		if err != nil {
 			return false, tinst, err
 		}
 
-		// Convert the templated resource into a service catalog resource
-		inst, err = builder.BuildServiceInstance(tinst)
-		if err != nil {
-			return false, tinst, err
+		// The namespace or the templates may select the Container provider instead
+		if builder.UsesContainerProvider(tinst) {
+			return s.synchronizeContainerInstance(tinst)
 		}
-		inst, err = s.svcatSDK.ServiceCatalog().ServiceInstances(tinst.Namespace).Create(inst)
+
+		inst, err = s.svcatSDK.ServiceCatalog().ServiceInstances(tinst.Namespace).Create(resolution.ServiceInstance)
 	}
 
 	// If an error occurs during Get/Create, we'll requeue the item so we can
@@ -161,13 +179,28 @@ func (s *Synchronizer) SynchronizeInstance(key string) (bool, runtime.Object, er
 		return false, tinst, err
 	}
 
+	// Leave the service instance alone while it is being deleted, the delete
+	// event will queue the templated instance again once it is gone.
+	if inst.DeletionTimestamp != nil {
+		glog.V(4).Infof("Service instance %s is being deleted, waiting for it to be removed", inst.SelfLink)
+		return false, tinst, nil
+	}
+
 	// TODO: Detect when the plan must be re-resolved
 
-	// If this number of the replicas on the TemplatedInstance resource is specified, and the
-	// number does not equal the current desired replicas on the Deployment, we
-	// should update the Deployment resource.
-	if tinst.Spec.Parameters != nil && (inst.Spec.Parameters == nil || string(tinst.Spec.Parameters.Raw) != string(inst.Spec.Parameters.Raw)) {
-		glog.V(4).Infof("Syncing instance %s back to service instance %s", tinst.SelfLink, inst.SelfLink)
+	// Compare the spec rendered from the TemplatedInstance with the live
+	// ServiceInstance. Depending on the drift policy, we either push the
+	// rendered spec back to the ServiceInstance or only report the drift.
+	desiredInst, err := builder.BuildServiceInstance(tinst)
+	if err != nil {
+		return false, tinst, err
+	}
+	drift, err := builder.DiffServiceInstance(desiredInst, inst)
+	if err != nil {
+		return false, tinst, err
+	}
+	if len(builder.CorrectableDrift(drift)) > 0 && builder.ShouldCorrectDrift(tinst.Spec.DriftPolicy) {
+		glog.V(4).Infof("Syncing instance %s back to service instance %s: %s", tinst.SelfLink, inst.SelfLink, builder.FormatDrift(drift))
 		inst = builder.RefreshServiceInstance(tinst, inst)
 		inst, err = s.svcatSDK.ServiceCatalog().ServiceInstances(inst.Namespace).Update(inst)
 	}
@@ -184,7 +217,108 @@ func (s *Synchronizer) SynchronizeInstance(key string) (bool, runtime.Object, er
 	//
 	// Finally, we update the status block of the TemplatedInstance resource to reflect the
 	// current state of the world
-	err = s.updateInstanceStatus(tinst, inst)
+	err = s.updateInstanceStatus(tinst, inst, drift)
+	if err != nil {
+		return false, tinst, err
+	}
+
+	// The status now reports the failure, the service catalog retries the broker
+	if failed, message := builder.GetInstanceFailure(inst); failed {
+		return false, tinst, sdkerrors.NewBrokerFailure("%s", message)
+	}
+
+	return true, tinst, nil
+}
+
+// resolveInstance applies the templates to a templated instance and saves the
+// result. The provider selected by the namespace label is applied first, so
+// that it takes precedence over the provider of the templates.
+func (s *Synchronizer) resolveInstance(tinst *templates.TemplatedInstance) (*templates.TemplatedInstance, *servicecatalogtempltesdk.InstanceResolution, error) {
+	if tinst.Spec.Provider == "" {
+		provider, err := s.coreSDK.GetNamespaceProvider(tinst.Namespace)
+		if err != nil {
+			return tinst, nil, err
+		}
+		tinst.Spec.Provider = provider
+	}
+
+	resolution, err := s.templateSDK.ResolveInstance(tinst)
+	if err != nil {
+		metrics.ObserveInstanceResolution(tinst.Spec.ServiceType, nil, err)
+		return tinst, nil, err
+	}
+	metrics.ObserveInstanceResolution(tinst.Spec.ServiceType, resolution.Templates, nil)
+
+	resolved, err := s.templateSDK.Templates().TemplatedInstances(tinst.Namespace).Update(resolution.TemplatedInstance)
+	if err != nil {
+		return resolution.TemplatedInstance, nil, err
+	}
+	return resolved, resolution, nil
+}
+
+// synchronizeContainerInstance deploys the service of a templated instance with
+// the Container provider, in place of a service instance. The instance is ready
+// once its deployment is available.
+func (s *Synchronizer) synchronizeContainerInstance(tinst *templates.TemplatedInstance) (bool, runtime.Object, error) {
+	var err error
+	if tinst.Spec.Container == nil {
+		tinst, _, err = s.resolveInstance(tinst)
+		if err != nil {
+			return false, tinst, err
+		}
+	}
+
+	// Compare the deployment and service rendered from the TemplatedInstance with
+	// the live resources. Depending on the drift policy, we either push the
+	// rendered spec back or only report the drift.
+	desiredDeployment := builder.BuildContainerDeployment(tinst)
+	deployment, err := s.coreSDK.GetDeployment(tinst.Namespace, tinst.Name)
+	var drift []templates.FieldDrift
+	if apierrors.IsNotFound(err) {
+		if builder.WasProvisioned(tinst.Status.Conditions) && !builder.ShouldCorrectDrift(tinst.Spec.DriftPolicy) {
+			glog.V(4).Infof("Deployment for %s was deleted, not recreating it because of the drift policy", tinst.SelfLink)
+			return false, tinst, s.updateInstanceDeletedStatus(tinst, "deployment")
+		}
+		glog.V(4).Infof("Deploying the container for instance %s", tinst.SelfLink)
+		deployment, err = s.coreSDK.Apps().Deployments(tinst.Namespace).Create(desiredDeployment)
+	} else if err == nil && !meta.IsControlledBy(deployment, tinst) {
+		err = sdkerrors.NewUnmanagedResource(deployment.Name)
+	} else if err == nil {
+		deploymentDrift := builder.DiffContainerDeployment(desiredDeployment, deployment)
+		if len(deploymentDrift) > 0 && builder.ShouldCorrectDrift(tinst.Spec.DriftPolicy) {
+			glog.V(4).Infof("Syncing instance %s back to deployment %s: %s", tinst.SelfLink, deployment.SelfLink, builder.FormatDrift(deploymentDrift))
+			deployment = builder.RefreshContainerDeployment(desiredDeployment, deployment)
+			deployment, err = s.coreSDK.Apps().Deployments(deployment.Namespace).Update(deployment)
+		}
+		drift = append(drift, prefixDrift("deployment", deploymentDrift)...)
+	}
+	if err != nil {
+		return false, tinst, err
+	}
+
+	desiredService := builder.BuildContainerService(tinst)
+	service, err := s.coreSDK.GetService(tinst.Namespace, tinst.Name)
+	if apierrors.IsNotFound(err) {
+		_, err = s.coreSDK.Core().Services(tinst.Namespace).Create(desiredService)
+	} else if err == nil && !meta.IsControlledBy(service, tinst) {
+		err = sdkerrors.NewUnmanagedResource(service.Name)
+	} else if err == nil {
+		serviceDrift := builder.DiffContainerService(desiredService, service)
+		if len(serviceDrift) > 0 && builder.ShouldCorrectDrift(tinst.Spec.DriftPolicy) {
+			glog.V(4).Infof("Syncing instance %s back to service %s: %s", tinst.SelfLink, service.SelfLink, builder.FormatDrift(serviceDrift))
+			service = builder.RefreshContainerService(desiredService, service)
+			_, err = s.coreSDK.Core().Services(service.Namespace).Update(service)
+		}
+		drift = append(drift, prefixDrift("service", serviceDrift)...)
+	}
+	if err != nil {
+		return false, tinst, err
+	}
+
+	tinst.Status.Conditions = builder.SetDeploymentReadyStatus(tinst.Status.Conditions, deployment)
+	tinst.Status.Conditions, tinst.Status.Drift = builder.SetDriftStatus(tinst.Status.Conditions, drift, tinst.Spec.DriftPolicy)
+	tinst.Status.Conditions = builder.SetSyncedStatus(tinst.Status.Conditions)
+	_, err = s.templateSDK.Templates().TemplatedInstances(tinst.Namespace).Update(tinst)
 	if err != nil {
 		return false, tinst, err
 	}
@@ -192,8 +326,26 @@ func (s *Synchronizer) SynchronizeInstance(key string) (bool, runtime.Object, er
 	return true, tinst, nil
 }
 
-func (s *Synchronizer) updateInstanceStatus(inst *templates.TemplatedInstance, svcInst *svcat.ServiceInstance) error {
-	// TODO: add resolved fields to the status
+// prefixDrift qualifies the drifted fields with the kind of resource that they are on,
+// when the templated resource manages more than one resource.
+func prefixDrift(kind string, drift []templates.FieldDrift) []templates.FieldDrift {
+	for i := range drift {
+		drift[i].Field = kind + " " + drift[i].Field
+	}
+	return drift
+}
+
+func (s *Synchronizer) updateInstanceStatus(inst *templates.TemplatedInstance, svcInst *svcat.ServiceInstance, drift []templates.FieldDrift) error {
+	if svcInst.Spec.ClusterServiceClassRef != nil {
+		inst.Status.ResolvedClass = svcat.ObjectReference{Name: svcInst.Spec.ClusterServiceClassRef.Name}
+	}
+	if svcInst.Spec.ClusterServicePlanRef != nil {
+		inst.Status.ResolvedPlan = svcat.ObjectReference{Name: svcInst.Spec.ClusterServicePlanRef.Name}
+	}
+	inst.Status.Conditions = builder.SetInstanceReadyStatus(inst.Status.Conditions, svcInst)
+	inst.Status.Conditions, inst.Status.Drift = builder.SetDriftStatus(inst.Status.Conditions, drift, inst.Spec.DriftPolicy)
+	inst.Status.Conditions = builder.SetSyncedStatus(inst.Status.Conditions)
+
 	// Until #38113 is merged, we must use Update instead of UpdateStatus to
 	// update the Status block of the TemplatedInstance resource. UpdateStatus will not
 	// allow changes to the Spec of the resource, which is ideal for ensuring
@@ -202,12 +354,40 @@ func (s *Synchronizer) updateInstanceStatus(inst *templates.TemplatedInstance, s
 	return err
 }
 
+// updateInstanceErrorStatus records a synchronization error on the templated instance.
+// Failing to record the error is only logged, the error itself is reported by the caller.
+func (s *Synchronizer) updateInstanceErrorStatus(inst *templates.TemplatedInstance, syncErr error) {
+	var changed bool
+	inst = inst.DeepCopy()
+	inst.Status.Conditions, changed = builder.SetErrorStatus(inst.Status.Conditions, sdkerrors.Classify(syncErr))
+	if !changed {
+		return
+	}
+	if _, err := s.templateSDK.Templates().TemplatedInstances(inst.Namespace).Update(inst); err != nil {
+		glog.V(4).Infof("unable to record the error status of %s/%s (%s)", inst.Namespace, inst.Name, err)
+	}
+}
+
+func (s *Synchronizer) updateInstanceDeletedStatus(inst *templates.TemplatedInstance, resource string) error {
+	inst.Status.Conditions = builder.SetDeletedStatus(inst.Status.Conditions, resource)
+	_, err := s.templateSDK.Templates().TemplatedInstances(inst.Namespace).Update(inst)
+	return err
+}
+
 // SynchronizeBinding accepts an binding key (namespace/name)
 // and attempts to synchronize it with a service catalog binding.
 // * ok - Synchronization was successful.
 // * resource - The resource.
 // * error - Fatal synchronization error.
 func (s *Synchronizer) SynchronizeBinding(key string) (bool, runtime.Object, error) {
+	ok, obj, err := s.synchronizeBinding(key)
+	if tbnd, isBinding := obj.(*templates.TemplatedBinding); err != nil && isBinding && !sdkerrors.IsWaiting(err) {
+		s.updateBindingErrorStatus(tbnd, err)
+	}
+	return ok, obj, err
+}
+
+func (s *Synchronizer) synchronizeBinding(key string) (bool, runtime.Object, error) {
 	//
 	// Get shadow resource
 	//
@@ -237,6 +417,18 @@ func (s *Synchronizer) SynchronizeBinding(key string) (bool, runtime.Object, err
 		return false, nil, nil
 	}
 
+	// Bindings to an instance provided by a container get their credentials from the instance
+	tinst, err := s.templateSDK.GetInstanceFromCache(tbnd.Namespace, tbnd.Spec.TemplatedInstanceRef.Name)
+	if err == nil {
+		if builder.UsesContainerProvider(tinst) {
+			return s.synchronizeContainerBinding(tbnd, tinst)
+		}
+		if tinst.Spec.Provider == "" && !builder.WasProvisioned(tinst.Status.Conditions) {
+			// The provider is not known until the instance is resolved
+			return false, tbnd, sdkerrors.NewWaiting("waiting for instance %s to be resolved", tinst.Name)
+		}
+	}
+
 	//
 	// Sync shadow resource back to service catalog resource
 	//
@@ -244,28 +436,27 @@ func (s *Synchronizer) SynchronizeBinding(key string) (bool, runtime.Object, err
 	// Get the corresponding service catalog resource
 	bnd, err := s.templateSDK.GetManagedServiceBinding(tbnd)
 	if sdkerrors.IsUnmanagedResource(err) {
-		msg := fmt.Sprintf(MessageResourceExists, bnd.Name)
-		return false, tbnd, fmt.Errorf(msg)
+		return false, tbnd, err
 	} else if apierrors.IsNotFound(err) {
-		template, err := s.resolver.ResolveBindingTemplate(*tbnd)
-		if err != nil {
-			// TODO: Update status to unresolvable
-			return false, tbnd, err
+		// The service binding existed before, so it was deleted out-of-band.
+		// Only recreate it when the drift policy allows us to correct drift.
+		if builder.WasProvisioned(tbnd.Status.Conditions) {
+			if !builder.ShouldCorrectDrift(tbnd.Spec.DriftPolicy) {
+				glog.V(4).Infof("Service binding for %s was deleted, not recreating it because of the drift policy", key)
+				return false, tbnd, s.updateBindingDeletedStatus(tbnd)
+			}
+			glog.V(4).Infof("Service binding for %s was deleted, recreating it", key)
 		}
 
-		// Apply changes from the template to the instance
-		tbnd, err = builder.ApplyBindingTemplate(tbnd, template)
-		if err != nil {
-			return false, tbnd, err
-		}
-		tbnd, err = s.templateSDK.Templates().TemplatedBindings(tbnd.Namespace).Update(tbnd)
+		// Apply changes from the template to the binding, and convert the
+		// templated resource into a service catalog resource
+		var resolution *servicecatalogtempltesdk.BindingResolution
+		tbnd, resolution, err = s.resolveBinding(tbnd)
 		if err != nil {
 			return false, tbnd, err
 		}
 
-		// Convert the templated resource into a service catalog resource
-		bnd = builder.BuildServiceBinding(tbnd)
-		bnd, err = s.svcatSDK.ServiceCatalog().ServiceBindings(bnd.Namespace).Create(bnd)
+		bnd, err = s.svcatSDK.ServiceCatalog().ServiceBindings(tbnd.Namespace).Create(resolution.ServiceBinding)
 	}
 
 	// If an error occurs during Get/Create, we'll requeue the item so we can
@@ -275,12 +466,25 @@ func (s *Synchronizer) SynchronizeBinding(key string) (bool, runtime.Object, err
 		return false, tbnd, err
 	}
 
+	// Leave the service binding alone while it is being deleted, the delete
+	// event will queue the templated binding again once it is gone.
+	if bnd.DeletionTimestamp != nil {
+		glog.V(4).Infof("Service binding %s is being deleted, waiting for it to be removed", bnd.SelfLink)
+		return false, tbnd, nil
+	}
+
 	//
 	// Sync updates to shadow resource back to the service catalog resource
 	//
-	// TODO: sync other fields
-	if tbnd.Spec.Parameters != nil && (bnd.Spec.Parameters == nil || string(tbnd.Spec.Parameters.Raw) != string(bnd.Spec.Parameters.Raw)) {
-		glog.V(4).Infof("Syncing shadow binding %s back to service catalog binding %s", tbnd.SelfLink, bnd.SelfLink)
+	// Compare the spec rendered from the TemplatedBinding with the live
+	// ServiceBinding. Depending on the drift policy, we either push the
+	// rendered spec back to the ServiceBinding or only report the drift.
+	drift, err := builder.DiffServiceBinding(builder.BuildServiceBinding(tbnd), bnd)
+	if err != nil {
+		return false, tbnd, err
+	}
+	if len(builder.CorrectableDrift(drift)) > 0 && builder.ShouldCorrectDrift(tbnd.Spec.DriftPolicy) {
+		glog.V(4).Infof("Syncing shadow binding %s back to service catalog binding %s: %s", tbnd.SelfLink, bnd.SelfLink, builder.FormatDrift(drift))
 		bnd = builder.RefreshServiceBinding(tbnd, bnd)
 		bnd, err = s.svcatSDK.ServiceCatalog().ServiceBindings(bnd.Namespace).Update(bnd)
 	}
@@ -292,10 +496,79 @@ func (s *Synchronizer) SynchronizeBinding(key string) (bool, runtime.Object, err
 		return false, tbnd, err
 	}
 
+	// Label the secret created by service catalog so that it is cached by the secret informer
+	err = s.labelShadowSecret(tbnd, bnd)
+	if err != nil {
+		return false, tbnd, err
+	}
+
 	//
 	// Update shadow resource status with the service catalog resource state
 	//
-	err = s.updateBindingStatus(tbnd, bnd)
+	err = s.updateBindingStatus(tbnd, bnd, drift)
+	if err != nil {
+		return false, tbnd, err
+	}
+
+	// The status now reports the failure, the service catalog retries the broker
+	if failed, message := builder.GetBindingFailure(bnd); failed {
+		return false, tbnd, sdkerrors.NewBrokerFailure("%s", message)
+	}
+
+	return true, tbnd, nil
+}
+
+// resolveBinding applies the templates to a templated binding and saves the result.
+func (s *Synchronizer) resolveBinding(tbnd *templates.TemplatedBinding) (*templates.TemplatedBinding, *servicecatalogtempltesdk.BindingResolution, error) {
+	resolution, err := s.templateSDK.ResolveBinding(tbnd)
+	if err != nil {
+		metrics.ObserveBindingResolution(s.bindingServiceType(tbnd), nil, err)
+		return tbnd, nil, err
+	}
+	metrics.ObserveBindingResolution(s.bindingServiceType(tbnd), resolution.Templates, nil)
+
+	resolved, err := s.templateSDK.Templates().TemplatedBindings(tbnd.Namespace).Update(resolution.TemplatedBinding)
+	if err != nil {
+		return resolution.TemplatedBinding, nil, err
+	}
+	return resolved, resolution, nil
+}
+
+// synchronizeContainerBinding writes the credentials of an instance provided by
+// a container to the secret that service catalog would otherwise create for the
+// binding. The secret is then projected like any other binding secret.
+func (s *Synchronizer) synchronizeContainerBinding(tbnd *templates.TemplatedBinding, tinst *templates.TemplatedInstance) (bool, runtime.Object, error) {
+	var err error
+	if tbnd.Spec.SecretName == "" {
+		tbnd, _, err = s.resolveBinding(tbnd)
+		if err != nil {
+			return false, tbnd, err
+		}
+	}
+	if tinst.Spec.Container == nil {
+		return false, tbnd, sdkerrors.NewWaiting("waiting for instance %s to be resolved", tinst.Name)
+	}
+
+	desired := builder.BuildContainerSecret(tinst, tbnd)
+	secret, err := s.coreSDK.GetSecret(desired.Namespace, desired.Name)
+	if apierrors.IsNotFound(err) {
+		glog.V(4).Infof("Writing the container credentials of instance %s for binding %s", tinst.SelfLink, tbnd.SelfLink)
+		_, err = s.coreSDK.Core().Secrets(desired.Namespace).Create(desired)
+	} else if err == nil {
+		if !meta.IsControlledBy(secret, tbnd) {
+			err = sdkerrors.NewUnmanagedResource(secret.Name)
+		} else if !reflect.DeepEqual(secret.Data, desired.Data) {
+			secret.Data = desired.Data
+			_, err = s.coreSDK.Core().Secrets(secret.Namespace).Update(secret)
+		}
+	}
+	if err != nil {
+		return false, tbnd, err
+	}
+
+	tbnd.Status.Conditions = builder.SetCredentialsReadyStatus(tbnd.Status.Conditions)
+	tbnd.Status.Conditions = builder.SetSyncedStatus(tbnd.Status.Conditions)
+	_, err = s.templateSDK.Templates().TemplatedBindings(tbnd.Namespace).Update(tbnd)
 	if err != nil {
 		return false, tbnd, err
 	}
@@ -303,8 +576,47 @@ func (s *Synchronizer) SynchronizeBinding(key string) (bool, runtime.Object, err
 	return true, tbnd, nil
 }
 
-func (s *Synchronizer) updateBindingStatus(bnd *templates.TemplatedBinding, svcBnd *svcat.ServiceBinding) error {
-	// TODO: add resolved fields to the status
+// labelShadowSecret labels the secret that service catalog creates for a binding.
+// Service catalog does not copy the binding's labels to its secret, and the
+// secret informer only caches labeled secrets, so the secret is retrieved from the API.
+func (s *Synchronizer) labelShadowSecret(tbnd *templates.TemplatedBinding, bnd *svcat.ServiceBinding) error {
+	if _, err := s.coreSDK.GetSecretFromCache(bnd.Namespace, bnd.Spec.SecretName); err == nil {
+		// Already labeled
+		return nil
+	}
+
+	svcSecret, err := s.coreSDK.GetSecret(bnd.Namespace, bnd.Spec.SecretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The secret is created once the binding is ready
+			return nil
+		}
+		return err
+	}
+
+	if !meta.IsControlledBy(svcSecret, bnd) || !builder.LabelSecret(svcSecret, tbnd) {
+		return nil
+	}
+
+	glog.V(4).Infof("Labeling secret %s of binding %s", svcSecret.SelfLink, bnd.SelfLink)
+	_, err = s.coreSDK.Core().Secrets(svcSecret.Namespace).Update(svcSecret)
+	return err
+}
+
+// bindingServiceType looks up the service type of the templated instance referenced by a binding.
+func (s *Synchronizer) bindingServiceType(tbnd *templates.TemplatedBinding) string {
+	tinst, err := s.templateSDK.GetInstanceFromCache(tbnd.Namespace, tbnd.Spec.TemplatedInstanceRef.Name)
+	if err != nil {
+		return ""
+	}
+	return tinst.Spec.ServiceType
+}
+
+func (s *Synchronizer) updateBindingStatus(bnd *templates.TemplatedBinding, svcBnd *svcat.ServiceBinding, drift []templates.FieldDrift) error {
+	bnd.Status.Conditions = builder.SetBindingReadyStatus(bnd.Status.Conditions, svcBnd)
+	bnd.Status.Conditions, bnd.Status.Drift = builder.SetDriftStatus(bnd.Status.Conditions, drift, bnd.Spec.DriftPolicy)
+	bnd.Status.Conditions = builder.SetSyncedStatus(bnd.Status.Conditions)
+
 	// Until #38113 is merged, we must use Update instead of UpdateStatus to
 	// update the Status block of the TemplatedInstance resource. UpdateStatus will not
 	// allow changes to the Spec of the resource, which is ideal for ensuring
@@ -313,6 +625,26 @@ func (s *Synchronizer) updateBindingStatus(bnd *templates.TemplatedBinding, svcB
 	return err
 }
 
+// updateBindingErrorStatus records a synchronization error on the templated binding.
+// Failing to record the error is only logged, the error itself is reported by the caller.
+func (s *Synchronizer) updateBindingErrorStatus(bnd *templates.TemplatedBinding, syncErr error) {
+	var changed bool
+	bnd = bnd.DeepCopy()
+	bnd.Status.Conditions, changed = builder.SetErrorStatus(bnd.Status.Conditions, sdkerrors.Classify(syncErr))
+	if !changed {
+		return
+	}
+	if _, err := s.templateSDK.Templates().TemplatedBindings(bnd.Namespace).Update(bnd); err != nil {
+		glog.V(4).Infof("unable to record the error status of %s/%s (%s)", bnd.Namespace, bnd.Name, err)
+	}
+}
+
+func (s *Synchronizer) updateBindingDeletedStatus(bnd *templates.TemplatedBinding) error {
+	bnd.Status.Conditions = builder.SetDeletedStatus(bnd.Status.Conditions, "service binding")
+	_, err := s.templateSDK.Templates().TemplatedBindings(bnd.Namespace).Update(bnd)
+	return err
+}
+
 // SynchronizeSecret accepts a secret key (namespace/name)
 // and attempts to synchronize the bound secret with the template secret.
 // * ok - Synchronization was successful.
@@ -350,26 +682,31 @@ func (s *Synchronizer) SynchronizeSecret(key string) (bool, runtime.Object, erro
 	//
 	// Sync service catalog resource back to the shadow resource
 	//
+	tbnd, err := s.GetTemplatedBindingFromShadowSecret(svcSecret)
+	if err != nil {
+		return false, svcSecret, err
+	}
+	if tbnd == nil {
+		// ignore unmanaged secrets
+		return false, nil, nil
+	}
 
 	// Get the corresponding shadow resource
 	shadowSecretName := builder.BoundSecretName(svcSecret.Name)
 	secret, err := s.coreSDK.GetSecretFromCache(svcSecret.Namespace, shadowSecretName)
-	// If the resource doesn't exist, we'll create it
 	if apierrors.IsNotFound(err) {
-		tbnd, err := s.GetTemplatedBindingFromShadowSecret(svcSecret)
-		if err != nil {
-			return false, svcSecret, err
-		}
-		if tbnd == nil {
-			// ignore unmanaged secrets
-			return false, nil, nil
-		}
-
-		secret, err = builder.BuildBoundSecret(svcSecret, tbnd)
-		if err != nil {
-			return false, svcSecret, err
+		// Secrets projected before they were labeled are not in the cache
+		secret, err = s.coreSDK.GetSecret(svcSecret.Namespace, shadowSecretName)
+		if apierrors.IsNotFound(err) {
+			// If the resource doesn't exist, we'll create it
+			secret, err = builder.BuildBoundSecret(svcSecret, tbnd)
+			if err != nil {
+				return false, svcSecret, err
+			}
+			secret, err = s.coreSDK.Core().Secrets(secret.Namespace).Create(secret)
+		} else if err == nil && meta.IsControlledBy(secret, svcSecret) && builder.LabelSecret(secret, tbnd) {
+			secret, err = s.coreSDK.Core().Secrets(secret.Namespace).Update(secret)
 		}
-		secret, err = s.coreSDK.Core().Secrets(secret.Namespace).Create(secret)
 	}
 
 	// If an error occurs during Get/Create, we'll requeue the item so we can
@@ -388,15 +725,6 @@ func (s *Synchronizer) SynchronizeSecret(key string) (bool, runtime.Object, erro
 	//
 	// Sync updates to service catalog resource back to the shadow resource
 	//
-	tbnd, err := s.GetTemplatedBindingFromShadowSecret(svcSecret)
-	if err != nil {
-		return false, svcSecret, err
-	}
-	if tbnd == nil {
-		// ignore unmanaged secrets
-		return false, nil, nil
-	}
-
 	if refreshedSecret, changed := builder.RefreshSecret(svcSecret, tbnd, secret); changed {
 		secret, err = s.coreSDK.Core().Secrets(refreshedSecret.Namespace).Update(refreshedSecret)
 
@@ -419,17 +747,19 @@ func (s *Synchronizer) SynchronizeSecret(key string) (bool, runtime.Object, erro
 	return true, svcSecret, nil
 }
 
+// GetTemplatedBindingFromShadowSecret finds the templated binding that manages a
+// service catalog secret using the secret's labels.
 func (s *Synchronizer) GetTemplatedBindingFromShadowSecret(svcSecret *core.Secret) (*templates.TemplatedBinding, error) {
-	svcBnd, err := s.svcatSDK.GetSecretOwner(svcSecret)
-	if err != nil {
-		return nil, err
+	name, ok := svcSecret.Labels[templates.LabelTemplatedBinding]
+	if !ok {
+		return nil, nil
 	}
 
-	if svcBnd == nil {
+	tbnd, err := s.templateSDK.GetBindingFromCache(svcSecret.Namespace, name)
+	if apierrors.IsNotFound(err) {
 		return nil, nil
 	}
-
-	return s.templateSDK.GetBindingOwner(svcBnd)
+	return tbnd, err
 }
 
 func (s *Synchronizer) updateSecretStatus(secret *core.Secret, svcSecret *core.Secret) error {
@@ -0,0 +1,259 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package simulator
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// syncBinding binds a service binding once its instance is ready, by writing
+// the simulated credentials to the binding secret, and unbinds it on delete.
+func (s *Simulator) syncBinding(key string) (time.Duration, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return 0, nil
+	}
+
+	bnd, err := s.bindingLister.ServiceBindings(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	bnd = bnd.DeepCopy()
+
+	if bnd.DeletionTimestamp != nil {
+		return 0, s.unbind(bnd)
+	}
+
+	if isBindingReconciled(bnd) {
+		return 0, nil
+	}
+
+	// Wait for the instance, its updates queue the binding again
+	inst, err := s.instanceLister.ServiceInstances(bnd.Namespace).Get(bnd.Spec.ServiceInstanceRef.Name)
+	if apierrors.IsNotFound(err) {
+		return 0, s.updateBindingCondition(bnd, "ReferencesNonexistentInstance", "The binding references an instance that does not exist")
+	} else if err != nil {
+		return 0, err
+	}
+	if getInstanceCondition(inst, svcat.ServiceInstanceConditionReady) != svcat.ConditionTrue {
+		return 0, s.updateBindingCondition(bnd, "ErrorInstanceNotReady", "The binding references an instance that is not ready")
+	}
+
+	if bnd.Status.CurrentOperation == "" {
+		glog.V(4).Infof("Simulating bind of service binding %s/%s", bnd.Namespace, bnd.Name)
+		now := metav1.Now()
+		bnd.Status.CurrentOperation = svcat.ServiceBindingOperationBind
+		bnd.Status.OperationStartTime = &now
+		setBindingCondition(bnd, svcat.ServiceBindingConditionReady, svcat.ConditionFalse, "Binding", "The binding is being created")
+		removeBindingCondition(bnd, svcat.ServiceBindingConditionFailed)
+		_, err = s.svcatClient.ServicecatalogV1beta1().ServiceBindings(bnd.Namespace).UpdateStatus(bnd)
+		return s.config.BindDelay, err
+	}
+
+	if left := remaining(bnd.Status.OperationStartTime, s.config.BindDelay); left > 0 {
+		return left, nil
+	}
+	return 0, s.completeBind(bnd)
+}
+
+// isBindingReconciled determines if the last bind finished for the current
+// spec of the binding, either successfully or with a failure.
+func isBindingReconciled(bnd *svcat.ServiceBinding) bool {
+	if bnd.Status.ReconciledGeneration != bnd.Generation {
+		return false
+	}
+	return getBindingCondition(bnd, svcat.ServiceBindingConditionReady) == svcat.ConditionTrue ||
+		getBindingCondition(bnd, svcat.ServiceBindingConditionFailed) == svcat.ConditionTrue
+}
+
+func (s *Simulator) completeBind(bnd *svcat.ServiceBinding) error {
+	bnd.Status.CurrentOperation = ""
+	bnd.Status.OperationStartTime = nil
+	bnd.Status.ReconciledGeneration = bnd.Generation
+
+	if s.shouldFail(bnd.Annotations) {
+		glog.V(4).Infof("Simulating a failed bind of service binding %s/%s", bnd.Namespace, bnd.Name)
+		message := "The simulated broker failed to bind the instance"
+		bnd.Status.UnbindStatus = svcat.ServiceBindingUnbindStatusNotRequired
+		setBindingCondition(bnd, svcat.ServiceBindingConditionReady, svcat.ConditionFalse, "BindCallFailed", message)
+		setBindingCondition(bnd, svcat.ServiceBindingConditionFailed, svcat.ConditionTrue, "BindCallFailed", message)
+	} else {
+		glog.V(4).Infof("Simulating a successful bind of service binding %s/%s", bnd.Namespace, bnd.Name)
+		if err := s.injectSecret(bnd); err != nil {
+			return err
+		}
+		bnd.Status.UnbindStatus = svcat.ServiceBindingUnbindStatusRequired
+		setBindingCondition(bnd, svcat.ServiceBindingConditionReady, svcat.ConditionTrue, "InjectedBindResult", "Injected bind result")
+	}
+
+	_, err := s.svcatClient.ServicecatalogV1beta1().ServiceBindings(bnd.Namespace).UpdateStatus(bnd)
+	return err
+}
+
+// injectSecret creates or updates the binding secret, owned by the binding.
+func (s *Simulator) injectSecret(bnd *svcat.ServiceBinding) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bindingSecretName(bnd),
+			Namespace: bnd.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(bnd, svcat.SchemeGroupVersion.WithKind("ServiceBinding")),
+			},
+		},
+		Data: s.credentials(bnd),
+	}
+
+	secrets := s.coreClient.CoreV1().Secrets(bnd.Namespace)
+	existing, err := secrets.Get(secret.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = secrets.Create(secret)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	existing.Data = secret.Data
+	_, err = secrets.Update(existing)
+	return err
+}
+
+// credentials are the configured secret keys, or credentials derived from the instance name.
+func (s *Simulator) credentials(bnd *svcat.ServiceBinding) map[string][]byte {
+	data := map[string][]byte{}
+	if len(s.config.SecretKeys) > 0 {
+		for k, v := range s.config.SecretKeys {
+			data[k] = []byte(v)
+		}
+		return data
+	}
+
+	instance := bnd.Spec.ServiceInstanceRef.Name
+	data["host"] = []byte(instance + "." + bnd.Namespace + ".simulated.local")
+	data["port"] = []byte("5432")
+	data["database"] = []byte(instance)
+	data["username"] = []byte(bnd.Name)
+	data["password"] = []byte("simulated")
+	return data
+}
+
+// unbind deletes the binding secret and removes the service catalog finalizer.
+func (s *Simulator) unbind(bnd *svcat.ServiceBinding) error {
+	if !removeFinalizer(&bnd.ObjectMeta) {
+		return nil
+	}
+
+	glog.V(4).Infof("Simulating unbind of service binding %s/%s", bnd.Namespace, bnd.Name)
+	secrets := s.coreClient.CoreV1().Secrets(bnd.Namespace)
+	secret, err := secrets.Get(bindingSecretName(bnd), metav1.GetOptions{})
+	if err == nil && metav1.IsControlledBy(secret, bnd) {
+		err = secrets.Delete(secret.Name, &metav1.DeleteOptions{})
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if bnd.Status.UnbindStatus == svcat.ServiceBindingUnbindStatusRequired {
+		bnd.Status.UnbindStatus = svcat.ServiceBindingUnbindStatusSucceeded
+	}
+
+	// The service catalog controller clears its finalizer with a status update
+	_, err = s.svcatClient.ServicecatalogV1beta1().ServiceBindings(bnd.Namespace).UpdateStatus(bnd)
+	return err
+}
+
+// updateBindingCondition records why a binding is waiting, skipping the update when nothing changed.
+func (s *Simulator) updateBindingCondition(bnd *svcat.ServiceBinding, reason, message string) error {
+	for _, c := range bnd.Status.Conditions {
+		if c.Type == svcat.ServiceBindingConditionReady && c.Status == svcat.ConditionFalse && c.Reason == reason {
+			return nil
+		}
+	}
+	setBindingCondition(bnd, svcat.ServiceBindingConditionReady, svcat.ConditionFalse, reason, message)
+	_, err := s.svcatClient.ServicecatalogV1beta1().ServiceBindings(bnd.Namespace).UpdateStatus(bnd)
+	return err
+}
+
+// enqueueInstanceBindings queues the bindings of a service instance.
+func (s *Simulator) enqueueInstanceBindings(obj interface{}) {
+	inst, ok := obj.(*svcat.ServiceInstance)
+	if !ok {
+		return
+	}
+
+	bindings, err := s.bindingLister.ServiceBindings(inst.Namespace).List(labels.Everything())
+	if err != nil {
+		util.HandleError(err)
+		return
+	}
+	for _, bnd := range bindings {
+		if bnd.Spec.ServiceInstanceRef.Name == inst.Name {
+			s.enqueue(bnd, s.bindingQ)
+		}
+	}
+}
+
+func bindingSecretName(bnd *svcat.ServiceBinding) string {
+	if bnd.Spec.SecretName != "" {
+		return bnd.Spec.SecretName
+	}
+	return bnd.Name
+}
+
+func getBindingCondition(bnd *svcat.ServiceBinding, conditionType svcat.ServiceBindingConditionType) svcat.ConditionStatus {
+	for _, c := range bnd.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status
+		}
+	}
+	return svcat.ConditionUnknown
+}
+
+func setBindingCondition(bnd *svcat.ServiceBinding, conditionType svcat.ServiceBindingConditionType,
+	status svcat.ConditionStatus, reason, message string) {
+
+	for i := range bnd.Status.Conditions {
+		c := &bnd.Status.Conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status != status {
+			c.LastTransitionTime = metav1.Now()
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		return
+	}
+
+	bnd.Status.Conditions = append(bnd.Status.Conditions, svcat.ServiceBindingCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func removeBindingCondition(bnd *svcat.ServiceBinding, conditionType svcat.ServiceBindingConditionType) {
+	var conditions []svcat.ServiceBindingCondition
+	for _, c := range bnd.Status.Conditions {
+		if c.Type != conditionType {
+			conditions = append(conditions, c)
+		}
+	}
+	bnd.Status.Conditions = conditions
+}
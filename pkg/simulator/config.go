@@ -0,0 +1,93 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package simulator
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FailAnnotation can be set to "true" on a ServiceInstance or ServiceBinding
+// to make the simulated broker fail to provision or bind it.
+const FailAnnotation = "simulator.templates.servicecatalog.k8s.io/fail"
+
+// Config tunes the behavior of the simulated broker.
+type Config struct {
+	// ProvisionDelay is how long a ServiceInstance stays in the provisioning
+	// or updating state before it is ready.
+	ProvisionDelay time.Duration
+
+	// BindDelay is how long a ServiceBinding waits before its secret is created.
+	BindDelay time.Duration
+
+	// SecretKeys are the keys and values written to the binding secrets.
+	// When empty, credentials derived from the instance name are used.
+	SecretKeys map[string]string
+
+	// FailureRate is the fraction, between 0 and 1, of provision and bind
+	// requests that fail.
+	FailureRate float64
+
+	// ResyncPeriod is how often the informers resync their caches.
+	ResyncPeriod time.Duration
+}
+
+// NewConfig returns the default simulator configuration.
+func NewConfig() *Config {
+	return &Config{
+		ProvisionDelay: 5 * time.Second,
+		BindDelay:      time.Second,
+		ResyncPeriod:   30 * time.Second,
+	}
+}
+
+// AddFlags binds the configuration to command-line flags.
+func (c *Config) AddFlags(fs *flag.FlagSet) {
+	fs.DurationVar(&c.ProvisionDelay, "provision-delay", c.ProvisionDelay, "How long instances take to provision or update.")
+	fs.DurationVar(&c.BindDelay, "bind-delay", c.BindDelay, "How long bindings take to bind.")
+	fs.Var((*secretKeys)(&c.SecretKeys), "secret-key", "A key=value pair written to every binding secret. May be repeated. Defaults to credentials derived from the instance name.")
+	fs.Float64Var(&c.FailureRate, "failure-rate", c.FailureRate, "The fraction, between 0 and 1, of provision and bind requests that fail.")
+	fs.DurationVar(&c.ResyncPeriod, "resync-period", c.ResyncPeriod, "How often the informers resync their caches.")
+}
+
+// Validate checks that the configuration is usable.
+func (c *Config) Validate() error {
+	if c.ProvisionDelay < 0 || c.BindDelay < 0 {
+		return fmt.Errorf("the provision and bind delays cannot be negative")
+	}
+	if c.FailureRate < 0 || c.FailureRate > 1 {
+		return fmt.Errorf("the failure rate must be between 0 and 1")
+	}
+	return nil
+}
+
+// secretKeys is a repeatable key=value flag.
+type secretKeys map[string]string
+
+func (s *secretKeys) String() string {
+	if s == nil {
+		return ""
+	}
+	var pairs []string
+	for k, v := range *s {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (s *secretKeys) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("invalid secret key %q, expected key=value", value)
+	}
+	if *s == nil {
+		*s = map[string]string{}
+	}
+	(*s)[parts[0]] = parts[1]
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package simulator
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestConfig_AddFlags(t *testing.T) {
+	c := NewConfig()
+	fs := flag.NewFlagSet("simulator", flag.ContinueOnError)
+	c.AddFlags(fs)
+
+	err := fs.Parse([]string{"--secret-key", "host=localhost", "--secret-key", "uri=mysql://localhost:3306", "--failure-rate", "0.5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.SecretKeys) != 2 || c.SecretKeys["host"] != "localhost" || c.SecretKeys["uri"] != "mysql://localhost:3306" {
+		t.Fatalf("unexpected secret keys %v", c.SecretKeys)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fs.Parse([]string{"--secret-key", "=value"}); err == nil {
+		t.Fatal("expected a secret key without a name to be rejected")
+	}
+
+	c.FailureRate = 2
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected a failure rate over 1 to be rejected")
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package simulator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// blockedRetryDelay is how often a blocked deprovision is retried.
+const blockedRetryDelay = time.Second
+
+// syncInstance moves a service instance through provisioning, updating and
+// deprovisioning. Each operation takes the configured provision delay.
+func (s *Simulator) syncInstance(key string) (time.Duration, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return 0, nil
+	}
+
+	inst, err := s.instanceLister.ServiceInstances(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	inst = inst.DeepCopy()
+
+	if inst.DeletionTimestamp != nil {
+		return s.deprovisionInstance(inst)
+	}
+
+	if inst.Status.CurrentOperation == "" {
+		if isInstanceReconciled(inst) {
+			return 0, nil
+		}
+		return s.startInstanceOperation(inst)
+	}
+
+	if left := remaining(inst.Status.OperationStartTime, s.config.ProvisionDelay); left > 0 {
+		return left, nil
+	}
+	return 0, s.completeInstanceOperation(inst)
+}
+
+// isInstanceReconciled determines if the last operation on the instance
+// finished for its current spec, either successfully or with a failure.
+func isInstanceReconciled(inst *svcat.ServiceInstance) bool {
+	if getInstanceCondition(inst, svcat.ServiceInstanceConditionFailed) == svcat.ConditionTrue {
+		return inst.Status.ObservedGeneration == inst.Generation
+	}
+	return getInstanceCondition(inst, svcat.ServiceInstanceConditionReady) == svcat.ConditionTrue &&
+		inst.Status.ReconciledGeneration == inst.Generation
+}
+
+func (s *Simulator) startInstanceOperation(inst *svcat.ServiceInstance) (time.Duration, error) {
+	// Resolve the class and plan first, updating the references queues the instance again
+	if inst.Spec.ClusterServiceClassRef == nil || inst.Spec.ClusterServicePlanRef == nil {
+		if err := s.resolveReferences(inst); err != nil {
+			return 0, err
+		}
+		_, err := s.svcatClient.ServicecatalogV1beta1().ServiceInstances(inst.Namespace).UpdateReferences(inst)
+		return 0, err
+	}
+
+	operation := svcat.ServiceInstanceOperationProvision
+	reason, message := "Provisioning", "The instance is being provisioned asynchronously"
+	if inst.Status.ProvisionStatus == svcat.ServiceInstanceProvisionStatusProvisioned {
+		operation = svcat.ServiceInstanceOperationUpdate
+		reason, message = "UpdatingInstance", "The instance is being updated asynchronously"
+	}
+	glog.V(4).Infof("Simulating %s of service instance %s/%s", operation, inst.Namespace, inst.Name)
+
+	now := metav1.Now()
+	inst.Status.CurrentOperation = operation
+	inst.Status.AsyncOpInProgress = true
+	inst.Status.OperationStartTime = &now
+	inst.Status.ObservedGeneration = inst.Generation
+	setInstanceCondition(inst, svcat.ServiceInstanceConditionReady, svcat.ConditionFalse, reason, message)
+	removeInstanceCondition(inst, svcat.ServiceInstanceConditionFailed)
+
+	_, err := s.svcatClient.ServicecatalogV1beta1().ServiceInstances(inst.Namespace).UpdateStatus(inst)
+	return s.config.ProvisionDelay, err
+}
+
+func (s *Simulator) completeInstanceOperation(inst *svcat.ServiceInstance) error {
+	operation := inst.Status.CurrentOperation
+	inst.Status.CurrentOperation = ""
+	inst.Status.AsyncOpInProgress = false
+	inst.Status.OperationStartTime = nil
+
+	if s.shouldFail(inst.Annotations) {
+		glog.V(4).Infof("Simulating a failed %s of service instance %s/%s", operation, inst.Namespace, inst.Name)
+		reason := "ProvisionCallFailed"
+		if operation == svcat.ServiceInstanceOperationUpdate {
+			reason = "UpdateInstanceCallFailed"
+		} else {
+			inst.Status.ProvisionStatus = svcat.ServiceInstanceProvisionStatusNotProvisioned
+			inst.Status.DeprovisionStatus = svcat.ServiceInstanceDeprovisionStatusNotRequired
+		}
+		message := fmt.Sprintf("The simulated broker failed to %s the instance", operationVerb(operation))
+		setInstanceCondition(inst, svcat.ServiceInstanceConditionReady, svcat.ConditionFalse, reason, message)
+		setInstanceCondition(inst, svcat.ServiceInstanceConditionFailed, svcat.ConditionTrue, reason, message)
+	} else {
+		glog.V(4).Infof("Simulating a successful %s of service instance %s/%s", operation, inst.Namespace, inst.Name)
+		reason, message := "ProvisionedSuccessfully", "The instance was provisioned successfully"
+		if operation == svcat.ServiceInstanceOperationUpdate {
+			reason, message = "InstanceUpdatedSuccessfully", "The instance was updated successfully"
+		}
+		inst.Status.ProvisionStatus = svcat.ServiceInstanceProvisionStatusProvisioned
+		inst.Status.DeprovisionStatus = svcat.ServiceInstanceDeprovisionStatusRequired
+		inst.Status.ReconciledGeneration = inst.Status.ObservedGeneration
+		setInstanceCondition(inst, svcat.ServiceInstanceConditionReady, svcat.ConditionTrue, reason, message)
+	}
+
+	_, err := s.svcatClient.ServicecatalogV1beta1().ServiceInstances(inst.Namespace).UpdateStatus(inst)
+	return err
+}
+
+// deprovisionInstance removes the service catalog finalizer once the instance
+// has no bindings left, like the service catalog controller.
+func (s *Simulator) deprovisionInstance(inst *svcat.ServiceInstance) (time.Duration, error) {
+	if !removeFinalizer(&inst.ObjectMeta) {
+		return 0, nil
+	}
+
+	bindings, err := s.bindingLister.ServiceBindings(inst.Namespace).List(labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+	for _, bnd := range bindings {
+		if bnd.Spec.ServiceInstanceRef.Name == inst.Name {
+			glog.V(4).Infof("Deprovision of service instance %s/%s is blocked by binding %s", inst.Namespace, inst.Name, bnd.Name)
+			return blockedRetryDelay, nil
+		}
+	}
+
+	glog.V(4).Infof("Simulating deprovision of service instance %s/%s", inst.Namespace, inst.Name)
+	if inst.Status.DeprovisionStatus == svcat.ServiceInstanceDeprovisionStatusRequired {
+		inst.Status.DeprovisionStatus = svcat.ServiceInstanceDeprovisionStatusSucceeded
+	}
+	setInstanceCondition(inst, svcat.ServiceInstanceConditionReady, svcat.ConditionFalse,
+		"DeprovisionedSuccessfully", "The instance was deprovisioned successfully")
+
+	// The service catalog controller clears its finalizer with a status update
+	_, err = s.svcatClient.ServicecatalogV1beta1().ServiceInstances(inst.Namespace).UpdateStatus(inst)
+	return 0, err
+}
+
+// resolveReferences sets the class and plan references of an instance. When
+// the class or plan is not registered, its external name is used as its name
+// so that the simulator works without a catalog.
+func (s *Simulator) resolveReferences(inst *svcat.ServiceInstance) error {
+	className := inst.Spec.ClusterServiceClassName
+	if className == "" {
+		className = inst.Spec.ClusterServiceClassExternalName
+		classes, err := s.classLister.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		for _, class := range classes {
+			if class.Spec.ExternalName == inst.Spec.ClusterServiceClassExternalName {
+				className = class.Name
+				break
+			}
+		}
+	}
+
+	planName := inst.Spec.ClusterServicePlanName
+	if planName == "" {
+		planName = inst.Spec.ClusterServicePlanExternalName
+		plans, err := s.planLister.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		for _, plan := range plans {
+			if plan.Spec.ExternalName == inst.Spec.ClusterServicePlanExternalName && plan.Spec.ClusterServiceClassRef.Name == className {
+				planName = plan.Name
+				break
+			}
+		}
+	}
+
+	if className == "" || planName == "" {
+		return fmt.Errorf("service instance %s/%s does not specify a class and plan", inst.Namespace, inst.Name)
+	}
+	inst.Spec.ClusterServiceClassRef = &svcat.ClusterObjectReference{Name: className}
+	inst.Spec.ClusterServicePlanRef = &svcat.ClusterObjectReference{Name: planName}
+	return nil
+}
+
+func operationVerb(operation svcat.ServiceInstanceOperation) string {
+	if operation == svcat.ServiceInstanceOperationUpdate {
+		return "update"
+	}
+	return "provision"
+}
+
+func getInstanceCondition(inst *svcat.ServiceInstance, conditionType svcat.ServiceInstanceConditionType) svcat.ConditionStatus {
+	for _, c := range inst.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status
+		}
+	}
+	return svcat.ConditionUnknown
+}
+
+func setInstanceCondition(inst *svcat.ServiceInstance, conditionType svcat.ServiceInstanceConditionType,
+	status svcat.ConditionStatus, reason, message string) {
+
+	for i := range inst.Status.Conditions {
+		c := &inst.Status.Conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status != status {
+			c.LastTransitionTime = metav1.Now()
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		return
+	}
+
+	inst.Status.Conditions = append(inst.Status.Conditions, svcat.ServiceInstanceCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func removeInstanceCondition(inst *svcat.ServiceInstance, conditionType svcat.ServiceInstanceConditionType) {
+	var conditions []svcat.ServiceInstanceCondition
+	for _, c := range inst.Status.Conditions {
+		if c.Type != conditionType {
+			conditions = append(conditions, c)
+		}
+	}
+	inst.Status.Conditions = conditions
+}
+
+// removeFinalizer removes the service catalog finalizer, returning false when it was not set.
+func removeFinalizer(meta *metav1.ObjectMeta) bool {
+	var finalizers []string
+	found := false
+	for _, f := range meta.Finalizers {
+		if f == svcat.FinalizerServiceCatalog {
+			found = true
+			continue
+		}
+		finalizers = append(finalizers, f)
+	}
+	meta.Finalizers = finalizers
+	return found
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package simulator stands in for the service catalog controller and a
+// broker, so that the Templates controller can be run end to end without
+// provisioning real services. ServiceInstances are moved through provisioning
+// to Ready, and ServiceBindings get a secret with simulated credentials.
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	svcatclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	svcatinformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
+	svcatlisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+)
+
+// Simulator reconciles ServiceInstances and ServiceBindings the way the service
+// catalog controller would with a broker that always responds.
+type Simulator struct {
+	config      *Config
+	coreClient  kubernetes.Interface
+	svcatClient svcatclientset.Interface
+
+	informers      svcatinformers.SharedInformerFactory
+	instanceLister svcatlisters.ServiceInstanceLister
+	bindingLister  svcatlisters.ServiceBindingLister
+	classLister    svcatlisters.ClusterServiceClassLister
+	planLister     svcatlisters.ClusterServicePlanLister
+	cacheSynced    []cache.InformerSynced
+
+	instanceQ workqueue.RateLimitingInterface
+	bindingQ  workqueue.RateLimitingInterface
+}
+
+// New creates a simulator for the specified clients, which may be fakes.
+func New(config *Config, coreClient kubernetes.Interface, svcatClient svcatclientset.Interface) *Simulator {
+	informers := svcatinformers.NewSharedInformerFactory(svcatClient, config.ResyncPeriod)
+	catalog := informers.Servicecatalog().V1beta1()
+
+	s := &Simulator{
+		config:         config,
+		coreClient:     coreClient,
+		svcatClient:    svcatClient,
+		informers:      informers,
+		instanceLister: catalog.ServiceInstances().Lister(),
+		bindingLister:  catalog.ServiceBindings().Lister(),
+		classLister:    catalog.ClusterServiceClasses().Lister(),
+		planLister:     catalog.ClusterServicePlans().Lister(),
+		cacheSynced: []cache.InformerSynced{
+			catalog.ServiceInstances().Informer().HasSynced,
+			catalog.ServiceBindings().Informer().HasSynced,
+			catalog.ClusterServiceClasses().Informer().HasSynced,
+			catalog.ClusterServicePlans().Informer().HasSynced,
+		},
+		instanceQ: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SimulatedInstances"),
+		bindingQ:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SimulatedBindings"),
+	}
+
+	catalog.ServiceInstances().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			s.enqueue(obj, s.instanceQ)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			s.enqueue(new, s.instanceQ)
+			// Bindings wait for their instance to be ready
+			s.enqueueInstanceBindings(new)
+		},
+	})
+	catalog.ServiceBindings().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			s.enqueue(obj, s.bindingQ)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			s.enqueue(new, s.bindingQ)
+		},
+	})
+
+	return s
+}
+
+// Run starts the informers and the workers, and blocks until stopCh is closed.
+func (s *Simulator) Run(stopCh <-chan struct{}) error {
+	defer util.HandleCrash()
+	defer s.instanceQ.ShutDown()
+	defer s.bindingQ.ShutDown()
+
+	s.informers.Start(stopCh)
+
+	glog.Info("Waiting for the simulator caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh, s.cacheSynced...); !ok {
+		return fmt.Errorf("failed to wait for the simulator caches to sync")
+	}
+
+	glog.Info("Starting the simulator workers")
+	go wait.Until(func() {
+		for s.processNextWorkItem(s.instanceQ, s.syncInstance) {
+		}
+	}, time.Second, stopCh)
+	go wait.Until(func() {
+		for s.processNextWorkItem(s.bindingQ, s.syncBinding) {
+		}
+	}, time.Second, stopCh)
+
+	<-stopCh
+	glog.Info("Shutting down the simulator workers")
+	return nil
+}
+
+// syncHandler reconciles the resource with the specified key. It returns how
+// long to wait before the resource should be reconciled again, or zero when
+// it is done.
+type syncHandler func(key string) (time.Duration, error)
+
+func (s *Simulator) processNextWorkItem(q workqueue.RateLimitingInterface, sync syncHandler) bool {
+	obj, shutdown := q.Get()
+	if shutdown {
+		return false
+	}
+	defer q.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		q.Forget(obj)
+		util.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	requeueAfter, err := sync(key)
+	if err != nil {
+		util.HandleError(fmt.Errorf("error simulating '%s': %s", key, err))
+		q.AddRateLimited(key)
+		return true
+	}
+
+	q.Forget(key)
+	if requeueAfter > 0 {
+		q.AddAfter(key, requeueAfter)
+	}
+	return true
+}
+
+func (s *Simulator) enqueue(obj interface{}, q workqueue.RateLimitingInterface) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		util.HandleError(err)
+		return
+	}
+	q.Add(key)
+}
+
+// shouldFail decides if a simulated broker request fails, either because the
+// resource asks for it or at random according to the failure rate.
+func (s *Simulator) shouldFail(annotations map[string]string) bool {
+	if annotations[FailAnnotation] == "true" {
+		return true
+	}
+	return s.config.FailureRate > 0 && rand.Float64() < s.config.FailureRate
+}
+
+// remaining returns how much of a delay is left for an operation that
+// started at the specified time.
+func remaining(start *metav1.Time, delay time.Duration) time.Duration {
+	if start == nil {
+		return 0
+	}
+	left := delay - time.Since(start.Time)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
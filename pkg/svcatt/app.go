@@ -6,11 +6,15 @@ package svcatt
 import (
 	"fmt"
 
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
 	templatesclientset "github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-sdk"
 	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/kube"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type ServiceCatalogApp = svcat.App
@@ -20,14 +24,27 @@ type App struct {
 	*ServiceCatalogApp
 	*servicecatalogtempltesdk.SDK
 
+	// CoreClient reads the secrets projected for templated bindings.
+	CoreClient kubernetes.Interface
+
 	// CurrentNamespace is the namespace set in the current context.
 	CurrentNamespace string
 }
 
 // NewApp creates an svcat application.
 func NewApp(kubeConfig, kubeContext string) (*App, error) {
+	restConfig, ns, err := getClientConfig(kubeConfig, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize a service catalog templates client
-	cl, ns, err := getTemplatesClient(kubeConfig, kubeContext)
+	cl, err := templatesclientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	coreClient, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -41,14 +58,26 @@ func NewApp(kubeConfig, kubeContext string) (*App, error) {
 	app := &App{
 		ServiceCatalogApp: svcApp,
 		SDK:               servicecatalogtempltesdk.New(cl, nil, svcSDK),
+		CoreClient:        coreClient,
 		CurrentNamespace:  ns,
 	}
 
 	return app, nil
 }
 
-// getTemplatesClient creates a Service Catalog Templates config and client for a given kubeconfig context.
-func getTemplatesClient(kubeConfig, kubeContext string) (client *templatesclientset.Clientset, namespaces string, err error) {
+// NamespaceProvider retrieves the provider selected by the label of a namespace,
+// returning an empty provider when the namespace is not labeled.
+func (app *App) NamespaceProvider(ns string) (templates.Provider, error) {
+	namespace, err := app.CoreClient.CoreV1().Namespaces().Get(ns, meta.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to get namespace %s (%s)", ns, err)
+	}
+	return templates.Provider(namespace.Labels[templates.LabelProvider]), nil
+}
+
+// getClientConfig creates a Kubernetes client config for a given kubeconfig context,
+// and determines the namespace of the context.
+func getClientConfig(kubeConfig, kubeContext string) (*rest.Config, string, error) {
 	config := kube.GetConfig(kubeContext, kubeConfig)
 
 	currentNamespace, _, err := config.Namespace()
@@ -61,6 +90,5 @@ func getTemplatesClient(kubeConfig, kubeContext string) (client *templatesclient
 		return nil, "", fmt.Errorf("could not get Kubernetes config for context %q: %s", kubeContext, err)
 	}
 
-	client, err = templatesclientset.NewForConfig(restConfig)
-	return client, currentNamespace, err
+	return restConfig, currentNamespace, nil
 }
@@ -0,0 +1,480 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// ApplyAction is what applying the manifests does to a resource.
+type ApplyAction string
+
+const (
+	// ApplyCreate creates a resource that does not exist yet.
+	ApplyCreate ApplyAction = "create"
+
+	// ApplyUpdate changes a resource to match its manifest.
+	ApplyUpdate ApplyAction = "update"
+
+	// ApplyUnchanged leaves a resource that already matches its manifest.
+	ApplyUnchanged ApplyAction = "unchanged"
+
+	// ApplyPrune deletes a templated resource of the apply set that is no longer in the manifests.
+	ApplyPrune ApplyAction = "prune"
+)
+
+// ApplyRetries is how many times an update is attempted when the resource is changed concurrently.
+const ApplyRetries = 5
+
+// ApplyOptions configure how manifests are applied.
+type ApplyOptions struct {
+	// ApplySet is labeled on the templated resources that are applied, when set.
+	ApplySet string
+
+	// Prune deletes the templated resources labeled with the apply set that are not in the manifests.
+	Prune bool
+}
+
+// ApplyChange is a change that applying the manifests makes to a resource.
+type ApplyChange struct {
+	Action    ApplyAction
+	Kind      string
+	Namespace string
+	Name      string
+
+	// object is the resource from the manifest, when it is created or updated.
+	object runtime.Object
+}
+
+// PlanApply compares the templates and templated resources from manifests with the
+// cluster, and determines the changes needed to apply them. It fails when a resource
+// was changed since its manifest was written, or belongs to another apply set.
+// Templates are ordered before the instances and bindings that use them, and bindings
+// are pruned before their instances.
+func (app *App) PlanApply(objects []runtime.Object, opts ApplyOptions) ([]ApplyChange, error) {
+	if opts.ApplySet != "" {
+		if errs := validation.IsValidLabelValue(opts.ApplySet); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid apply set %q (%s)", opts.ApplySet, strings.Join(errs, ", "))
+		}
+	} else if opts.Prune {
+		return nil, fmt.Errorf("an apply set is required to prune")
+	}
+
+	changes := make([]ApplyChange, 0, len(objects))
+	applied := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		obj = obj.DeepCopyObject()
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		change := ApplyChange{
+			Kind:      objectKind(obj),
+			Namespace: accessor.GetNamespace(),
+			Name:      accessor.GetName(),
+			object:    obj,
+		}
+
+		key := change.key()
+		if applied[key] {
+			return nil, fmt.Errorf("%s is defined more than once in the manifests", change)
+		}
+		applied[key] = true
+
+		if opts.ApplySet != "" && isTemplatedResource(obj) {
+			labels := accessor.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[templates.LabelApplySet] = opts.ApplySet
+			accessor.SetLabels(labels)
+		}
+
+		live, err := app.getApplied(obj)
+		if apierrors.IsNotFound(err) {
+			change.Action = ApplyCreate
+			changes = append(changes, change)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to get %s (%s)", change, err)
+		}
+
+		if err := checkApplyConflict(change, live, obj, opts.ApplySet); err != nil {
+			return nil, err
+		}
+		change.Action = ApplyUnchanged
+		if !equality.Semantic.DeepEqual(mergeApplied(live, obj), live) {
+			change.Action = ApplyUpdate
+		}
+		changes = append(changes, change)
+	}
+
+	if opts.Prune {
+		pruned, err := app.planPrune(opts.ApplySet, applied)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, pruned...)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].order() < changes[j].order()
+	})
+	return changes, nil
+}
+
+// Apply makes the planned changes in order, calling done after each change is made.
+// Updates are merged into the current resource, and retried when it changes concurrently.
+func (app *App) Apply(changes []ApplyChange, done func(ApplyChange)) error {
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case ApplyCreate:
+			err = app.writeApplied(change.object, true)
+		case ApplyUpdate:
+			err = app.updateApplied(change.object)
+		case ApplyPrune:
+			if change.Kind == templates.BindingKind {
+				err = app.DeleteTemplatedBinding(change.Namespace, change.Name)
+			} else {
+				err = app.Deprovision(change.Namespace, change.Name)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("unable to %s %s (%s)", change.Action, change, err)
+		}
+		if done != nil {
+			done(change)
+		}
+	}
+	return nil
+}
+
+func (change ApplyChange) String() string {
+	if change.Namespace == "" {
+		return fmt.Sprintf("%s %s", change.Kind, change.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", change.Kind, change.Namespace, change.Name)
+}
+
+func (change ApplyChange) key() string {
+	return change.Kind + "/" + change.Namespace + "/" + change.Name
+}
+
+// order sorts templates first, then instances and bindings, and prunes bindings
+// before instances.
+func (change ApplyChange) order() int {
+	switch {
+	case change.Action == ApplyPrune && change.Kind == templates.BindingKind:
+		return 3
+	case change.Action == ApplyPrune:
+		return 4
+	case change.Kind == templates.InstanceKind:
+		return 1
+	case change.Kind == templates.BindingKind:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// checkApplyConflict fails when the manifest was written for an older version of the
+// resource, or the resource is managed by another apply set.
+func checkApplyConflict(change ApplyChange, live, applied runtime.Object, applySet string) error {
+	liveMeta, err := meta.Accessor(live)
+	if err != nil {
+		return err
+	}
+	appliedMeta, err := meta.Accessor(applied)
+	if err != nil {
+		return err
+	}
+
+	if v := appliedMeta.GetResourceVersion(); v != "" && v != liveMeta.GetResourceVersion() {
+		return fmt.Errorf("%s was changed since its manifest was written (resource version %s, the manifest has %s)",
+			change, liveMeta.GetResourceVersion(), v)
+	}
+	if owner := liveMeta.GetLabels()[templates.LabelApplySet]; applySet != "" && owner != "" && owner != applySet {
+		return fmt.Errorf("%s belongs to apply set %q", change, owner)
+	}
+	return nil
+}
+
+// planPrune finds the templated resources labeled with the apply set, in any namespace,
+// that were not applied.
+func (app *App) planPrune(applySet string, applied map[string]bool) ([]ApplyChange, error) {
+	opts := servicecatalogtempltesdk.TemplatedListOptions{
+		Selector: fmt.Sprintf("%s=%s", templates.LabelApplySet, applySet),
+	}
+
+	var changes []ApplyChange
+	add := func(kind string, obj metav1.Object) {
+		change := ApplyChange{Action: ApplyPrune, Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		if !applied[change.key()] {
+			changes = append(changes, change)
+		}
+	}
+
+	tbnds, err := app.ListTemplatedBindings("", opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tbnds.Items {
+		add(templates.BindingKind, &tbnds.Items[i])
+	}
+
+	tinsts, err := app.ListTemplatedInstances("", opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tinsts.Items {
+		add(templates.InstanceKind, &tinsts.Items[i])
+	}
+
+	return changes, nil
+}
+
+// updateApplied merges a manifest into the current resource and updates it, retrying
+// when the resource was changed concurrently.
+func (app *App) updateApplied(applied runtime.Object) error {
+	for i := 0; i < ApplyRetries; i++ {
+		live, err := app.getApplied(applied)
+		if err != nil {
+			return err
+		}
+
+		err = app.writeApplied(mergeApplied(live, applied), false)
+		if err == nil {
+			return nil
+		}
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("conflicting changes after %d tries", ApplyRetries)
+}
+
+// mergeApplied returns a copy of the current resource with the spec, labels and
+// annotations of its manifest. The controller resolves the specs of templated
+// resources from the templates, so only the fields that their manifest sets are merged.
+func mergeApplied(live, applied runtime.Object) runtime.Object {
+	merged := live.DeepCopyObject()
+	applied = applied.DeepCopyObject()
+
+	switch m := merged.(type) {
+	case *templates.InstanceTemplate:
+		m.Spec = applied.(*templates.InstanceTemplate).Spec
+	case *templates.ClusterInstanceTemplate:
+		m.Spec = applied.(*templates.ClusterInstanceTemplate).Spec
+	case *templates.BrokerInstanceTemplate:
+		m.Spec = applied.(*templates.BrokerInstanceTemplate).Spec
+	case *templates.BindingTemplate:
+		m.Spec = applied.(*templates.BindingTemplate).Spec
+	case *templates.ClusterBindingTemplate:
+		m.Spec = applied.(*templates.ClusterBindingTemplate).Spec
+	case *templates.BrokerBindingTemplate:
+		m.Spec = applied.(*templates.BrokerBindingTemplate).Spec
+	case *templates.TemplatedBinding:
+		mergeTemplatedBindingSpec(&m.Spec, applied.(*templates.TemplatedBinding).Spec)
+	case *templates.TemplatedInstance:
+		mergeTemplatedInstanceSpec(&m.Spec, applied.(*templates.TemplatedInstance).Spec)
+	}
+
+	mergedMeta, _ := meta.Accessor(merged)
+	appliedMeta, _ := meta.Accessor(applied)
+	mergedMeta.SetLabels(mergeStrings(mergedMeta.GetLabels(), appliedMeta.GetLabels()))
+	mergedMeta.SetAnnotations(mergeStrings(mergedMeta.GetAnnotations(), appliedMeta.GetAnnotations()))
+	return merged
+}
+
+// mergeTemplatedInstanceSpec sets the fields of the current spec that the manifest sets.
+func mergeTemplatedInstanceSpec(spec *templates.TemplatedInstanceSpec, applied templates.TemplatedInstanceSpec) {
+	if applied.ServiceType != "" {
+		spec.ServiceType = applied.ServiceType
+	}
+	if applied.PlanSelector != nil {
+		spec.PlanSelector = applied.PlanSelector
+	}
+	// The class and plan are replaced together, so that names and external names are not mixed
+	if applied.PlanReference != (svcat.PlanReference{}) {
+		spec.PlanReference = applied.PlanReference
+	}
+	if applied.Parameters != nil {
+		spec.Parameters = applied.Parameters
+	}
+	if len(applied.ParametersFrom) > 0 {
+		spec.ParametersFrom = applied.ParametersFrom
+	}
+	if applied.ExternalID != "" {
+		spec.ExternalID = applied.ExternalID
+	}
+	// Touch counts the update requests, keep them unless the manifest requests more
+	if applied.UpdateRequests > spec.UpdateRequests {
+		spec.UpdateRequests = applied.UpdateRequests
+	}
+	if applied.DriftPolicy != "" {
+		spec.DriftPolicy = applied.DriftPolicy
+	}
+	if applied.Provider != "" {
+		spec.Provider = applied.Provider
+	}
+	if applied.Container != nil {
+		spec.Container = applied.Container
+	}
+}
+
+// mergeTemplatedBindingSpec sets the fields of the current spec that the manifest sets.
+// The secret keys of the manifest are added to those merged from the templates.
+func mergeTemplatedBindingSpec(spec *templates.TemplatedBindingSpec, applied templates.TemplatedBindingSpec) {
+	if applied.TemplatedInstanceRef.Name != "" {
+		spec.TemplatedInstanceRef = applied.TemplatedInstanceRef
+	}
+	if applied.Parameters != nil {
+		spec.Parameters = applied.Parameters
+	}
+	if len(applied.ParametersFrom) > 0 {
+		spec.ParametersFrom = applied.ParametersFrom
+	}
+	spec.SecretKeys = mergeStrings(spec.SecretKeys, applied.SecretKeys)
+	if applied.SecretName != "" {
+		spec.SecretName = applied.SecretName
+	}
+	if applied.ExternalID != "" {
+		spec.ExternalID = applied.ExternalID
+	}
+	if applied.DriftPolicy != "" {
+		spec.DriftPolicy = applied.DriftPolicy
+	}
+}
+
+// mergeStrings adds the applied values to the current values.
+func mergeStrings(current, applied map[string]string) map[string]string {
+	if len(applied) == 0 {
+		return current
+	}
+	if current == nil {
+		current = make(map[string]string, len(applied))
+	}
+	for k, v := range applied {
+		current[k] = v
+	}
+	return current
+}
+
+func (app *App) getApplied(obj runtime.Object) (runtime.Object, error) {
+	c := app.Templates()
+	opts := metav1.GetOptions{}
+	switch o := obj.(type) {
+	case *templates.InstanceTemplate:
+		return c.InstanceTemplates(o.Namespace).Get(o.Name, opts)
+	case *templates.ClusterInstanceTemplate:
+		return c.ClusterInstanceTemplates().Get(o.Name, opts)
+	case *templates.BrokerInstanceTemplate:
+		return c.BrokerInstanceTemplates().Get(o.Name, opts)
+	case *templates.BindingTemplate:
+		return c.BindingTemplates(o.Namespace).Get(o.Name, opts)
+	case *templates.ClusterBindingTemplate:
+		return c.ClusterBindingTemplates().Get(o.Name, opts)
+	case *templates.BrokerBindingTemplate:
+		return c.BrokerBindingTemplates().Get(o.Name, opts)
+	case *templates.TemplatedInstance:
+		return c.TemplatedInstances(o.Namespace).Get(o.Name, opts)
+	case *templates.TemplatedBinding:
+		return c.TemplatedBindings(o.Namespace).Get(o.Name, opts)
+	default:
+		return nil, fmt.Errorf("unsupported resource type %T", obj)
+	}
+}
+
+// writeApplied creates or updates a resource.
+func (app *App) writeApplied(obj runtime.Object, create bool) error {
+	c := app.Templates()
+	var err error
+	switch o := obj.(type) {
+	case *templates.InstanceTemplate:
+		client := c.InstanceTemplates(o.Namespace)
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	case *templates.ClusterInstanceTemplate:
+		client := c.ClusterInstanceTemplates()
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	case *templates.BrokerInstanceTemplate:
+		client := c.BrokerInstanceTemplates()
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	case *templates.BindingTemplate:
+		client := c.BindingTemplates(o.Namespace)
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	case *templates.ClusterBindingTemplate:
+		client := c.ClusterBindingTemplates()
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	case *templates.BrokerBindingTemplate:
+		client := c.BrokerBindingTemplates()
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	case *templates.TemplatedInstance:
+		client := c.TemplatedInstances(o.Namespace)
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	case *templates.TemplatedBinding:
+		client := c.TemplatedBindings(o.Namespace)
+		write := client.Update
+		if create {
+			write = client.Create
+		}
+		_, err = write(o)
+	default:
+		err = fmt.Errorf("unsupported resource type %T", obj)
+	}
+	return err
+}
+
+func objectKind(obj runtime.Object) string {
+	return strings.Split(fmt.Sprintf("%T", obj), ".")[1]
+}
+
+func isTemplatedResource(obj runtime.Object) bool {
+	switch obj.(type) {
+	case *templates.TemplatedInstance, *templates.TemplatedBinding:
+		return true
+	}
+	return false
+}
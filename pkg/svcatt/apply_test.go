@@ -0,0 +1,213 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// inApplySet labels a templated resource with the apply set that manages it.
+func inApplySet(applySet string) map[string]string {
+	return map[string]string{templates.LabelApplySet: applySet}
+}
+
+func TestApply_Prune(t *testing.T) {
+	resolved := newTestTemplatedInstance("unchanged", "mysqldb", templates.ProviderServiceCatalog, inApplySet("wordpress"))
+	sdk, err := servicecatalogtempltesdk.NewOffline(
+		resolved,
+		newTestTemplatedInstance("changed", "mysqldb", "", inApplySet("wordpress")),
+		newTestTemplatedInstance("removed", "mysqldb", "", inApplySet("wordpress")),
+		newTestTemplatedInstance("unmanaged", "mysqldb", "", nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &App{SDK: sdk}
+
+	manifests := []runtime.Object{
+		newTestTemplatedInstance("added", "redis", "", nil),
+		newTestTemplatedInstance("changed", "redis", "", nil),
+		newTestTemplatedInstance("unchanged", "mysqldb", "", nil),
+	}
+	changes, err := app.PlanApply(manifests, ApplyOptions{ApplySet: "wordpress", Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]ApplyAction{
+		"added":     ApplyCreate,
+		"changed":   ApplyUpdate,
+		"unchanged": ApplyUnchanged,
+		"removed":   ApplyPrune,
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), changes)
+	}
+	for _, change := range changes {
+		if want[change.Name] != change.Action {
+			t.Fatalf("expected to %s %s, got %s", want[change.Name], change.Name, change.Action)
+		}
+	}
+
+	if err := app.Apply(changes, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tinsts, err := app.ListTemplatedInstances("default", servicecatalogtempltesdk.TemplatedListOptions{
+		Selector: templates.LabelApplySet + "=wordpress",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tinsts.Items) != 3 {
+		t.Fatalf("expected 3 instances in the apply set, got %d", len(tinsts.Items))
+	}
+	changed, err := app.RetrieveTemplatedInstance("default", "changed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.Spec.ServiceType != "redis" {
+		t.Fatalf("expected the changed instance to be updated, got service type %s", changed.Spec.ServiceType)
+	}
+}
+
+func TestPlanApply_ApplySetConflict(t *testing.T) {
+	sdk, err := servicecatalogtempltesdk.NewOffline(newTestTemplatedInstance("shared", "mysqldb", "", inApplySet("ghost")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &App{SDK: sdk}
+
+	_, err = app.PlanApply([]runtime.Object{newTestTemplatedInstance("shared", "mysqldb", "", nil)}, ApplyOptions{ApplySet: "wordpress"})
+	if err == nil {
+		t.Fatal("expected applying an instance of another apply set to fail")
+	}
+}
+
+func TestPlanApply_KeepsResolvedSpec(t *testing.T) {
+	tinst := newTestTemplatedInstance("wordpress-db", "mysqldb", "", nil)
+	resolved := tinst.DeepCopy()
+	resolved.Spec.Provider = templates.ProviderServiceCatalog
+	resolved.Spec.PlanReference = svcat.PlanReference{
+		ClusterServiceClassExternalName: "azure-mysqldb",
+		ClusterServicePlanExternalName:  "basic50",
+	}
+	resolved.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"location":"eastus"}`)}
+
+	tbnd := &templates.TemplatedBinding{
+		ObjectMeta: meta.ObjectMeta{Name: "wordpress-db", Namespace: "default"},
+		Spec: templates.TemplatedBindingSpec{
+			TemplatedInstanceRef: svcat.LocalObjectReference{Name: "wordpress-db"},
+			SecretKeys:           map[string]string{"host": "MYSQL_HOST"},
+		},
+	}
+	defaulted := tbnd.DeepCopy()
+	defaulted.Spec.SecretName = "wordpress-db"
+	defaulted.Spec.SecretKeys["port"] = "MYSQL_PORT"
+
+	sdk, err := servicecatalogtempltesdk.NewOffline(resolved, defaulted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &App{SDK: sdk}
+
+	changes, err := app.PlanApply([]runtime.Object{tinst, tbnd}, ApplyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, change := range changes {
+		if change.Action != ApplyUnchanged {
+			t.Fatalf("expected %s to be unchanged, got %s", change, change.Action)
+		}
+	}
+
+	tinst.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"location":"westus"}`)}
+	changes, err = app.PlanApply([]runtime.Object{tinst, tbnd}, ApplyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Apply(changes, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	gotInst, err := app.RetrieveTemplatedInstance("default", "wordpress-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotInst.Spec.PlanReference != resolved.Spec.PlanReference {
+		t.Fatalf("expected the plan to be kept, got %#v", gotInst.Spec.PlanReference)
+	}
+	if string(gotInst.Spec.Parameters.Raw) != `{"location":"westus"}` {
+		t.Fatalf("expected the parameters to be updated, got %s", gotInst.Spec.Parameters.Raw)
+	}
+
+	gotBnd, err := app.RetrieveTemplatedBinding("default", "wordpress-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotBnd.Spec.SecretName != "wordpress-db" || !reflect.DeepEqual(gotBnd.Spec.SecretKeys, defaulted.Spec.SecretKeys) {
+		t.Fatalf("expected the secret to be kept, got %#v", gotBnd.Spec)
+	}
+}
+
+func TestPlanApply_ResourceVersion(t *testing.T) {
+	live := newTestTemplatedInstance("wordpress-db", "mysqldb", "", nil)
+	live.ResourceVersion = "3"
+
+	testcases := []struct {
+		name            string
+		resourceVersion string
+		wantErr         bool
+	}{
+		{name: "without resource version"},
+		{name: "current resource version", resourceVersion: "3"},
+		{name: "stale resource version", resourceVersion: "2", wantErr: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sdk, err := servicecatalogtempltesdk.NewOffline(live.DeepCopy())
+			if err != nil {
+				t.Fatal(err)
+			}
+			app := &App{SDK: sdk}
+
+			manifest := newTestTemplatedInstance("wordpress-db", "redis", "", nil)
+			manifest.ResourceVersion = tc.resourceVersion
+			changes, err := app.PlanApply([]runtime.Object{manifest}, ApplyOptions{})
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "was changed since its manifest was written") {
+					t.Fatalf("expected the stale manifest to be rejected, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changes) != 1 || changes[0].Action != ApplyUpdate {
+				t.Fatalf("expected the instance to be updated, got %v", changes)
+			}
+
+			if err := app.Apply(changes, nil); err != nil {
+				t.Fatal(err)
+			}
+			got, err := app.RetrieveTemplatedInstance("default", "wordpress-db")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Spec.ServiceType != "redis" {
+				t.Fatalf("expected the live instance to be updated, got service type %s", got.Spec.ServiceType)
+			}
+		})
+	}
+}
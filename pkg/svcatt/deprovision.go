@@ -0,0 +1,234 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+)
+
+// SecretConsumer is a workload that references a secret projected for a templated binding.
+type SecretConsumer struct {
+	Kind    string
+	Name    string
+	Secret  string
+	Binding string
+}
+
+// DeprovisionPlan lists what is affected by deprovisioning a templated instance.
+type DeprovisionPlan struct {
+	Instance  *templates.TemplatedInstance
+	Bindings  []templates.TemplatedBinding
+	Consumers []SecretConsumer
+
+	// Unscanned are the kinds of workload that could not be listed, so whether
+	// they reference the secrets is unknown.
+	Unscanned []string
+}
+
+// PlanDeprovision finds the bindings to a templated instance, and the workloads in
+// its namespace that reference the secrets projected for those bindings.
+func (app *App) PlanDeprovision(ns, instanceName string) (*DeprovisionPlan, error) {
+	tinst, err := app.RetrieveTemplatedInstance(ns, instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings, err := app.RetrieveTemplatedBindingsByInstance(tinst)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &DeprovisionPlan{
+		Instance:  tinst,
+		Bindings:  bindings,
+		Consumers: []SecretConsumer{},
+	}
+	if len(bindings) == 0 {
+		return plan, nil
+	}
+
+	plan.Consumers, plan.Unscanned, err = app.FindSecretConsumers(ns, bindings)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// FindSecretConsumers returns the pods, deployments, replica sets, stateful sets and
+// daemon sets that reference the secrets projected for the bindings. Pods and replica
+// sets are reported through their controller, when the workloads of that kind were
+// checked. The kinds of workload that the user is not allowed to list are returned
+// as unscanned, instead of failing.
+func (app *App) FindSecretConsumers(ns string, bindings []templates.TemplatedBinding) ([]SecretConsumer, []string, error) {
+	secrets := make(map[string]string, len(bindings))
+	for _, tbnd := range bindings {
+		if tbnd.Spec.SecretName != "" {
+			secrets[tbnd.Spec.SecretName] = tbnd.Name
+		}
+	}
+
+	consumers := []SecretConsumer{}
+	add := func(kind, name string, spec core.PodSpec) {
+		for _, secret := range podSpecSecrets(spec) {
+			if binding, ok := secrets[secret]; ok {
+				consumers = append(consumers, SecretConsumer{Kind: kind, Name: name, Secret: secret, Binding: binding})
+			}
+		}
+	}
+
+	// scanned are the kinds of controller whose pod templates were checked
+	scanned := map[string]bool{}
+	var unscanned []string
+	listed := func(kind string, err error) (bool, error) {
+		if apierrors.IsForbidden(err) {
+			unscanned = append(unscanned, kind)
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("unable to list %s in %s (%s)", kind, ns, err)
+		}
+		return true, nil
+	}
+	isReported := func(obj meta.Object) bool {
+		owner := meta.GetControllerOf(obj)
+		return owner != nil && scanned[owner.Kind]
+	}
+
+	deployments, err := app.CoreClient.AppsV1().Deployments(ns).List(meta.ListOptions{})
+	ok, err := listed("deployments", err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		scanned["Deployment"] = true
+		for _, d := range deployments.Items {
+			add("deployment", d.Name, d.Spec.Template.Spec)
+		}
+	}
+
+	replicaSets, err := app.CoreClient.AppsV1().ReplicaSets(ns).List(meta.ListOptions{})
+	ok, err = listed("replica sets", err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		scanned["ReplicaSet"] = true
+		for _, rs := range replicaSets.Items {
+			if !isReported(&rs) {
+				add("replicaset", rs.Name, rs.Spec.Template.Spec)
+			}
+		}
+	}
+
+	statefulSets, err := app.CoreClient.AppsV1().StatefulSets(ns).List(meta.ListOptions{})
+	ok, err = listed("stateful sets", err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		scanned["StatefulSet"] = true
+		for _, s := range statefulSets.Items {
+			add("statefulset", s.Name, s.Spec.Template.Spec)
+		}
+	}
+
+	daemonSets, err := app.CoreClient.AppsV1().DaemonSets(ns).List(meta.ListOptions{})
+	ok, err = listed("daemon sets", err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		scanned["DaemonSet"] = true
+		for _, d := range daemonSets.Items {
+			add("daemonset", d.Name, d.Spec.Template.Spec)
+		}
+	}
+
+	// Pods of jobs, or of any other controller that was not checked, are reported themselves
+	pods, err := app.CoreClient.CoreV1().Pods(ns).List(meta.ListOptions{})
+	ok, err = listed("pods", err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		for _, pod := range pods.Items {
+			if !isReported(&pod) {
+				add("pod", pod.Name, pod.Spec)
+			}
+		}
+	}
+
+	return consumers, unscanned, nil
+}
+
+// podSpecSecrets returns the sorted names of the secrets that a pod mounts or reads
+// into its environment.
+func podSpecSecrets(spec core.PodSpec) []string {
+	found := map[string]bool{}
+
+	for _, v := range spec.Volumes {
+		if v.Secret != nil {
+			found[v.Secret.SecretName] = true
+		}
+		if v.Projected != nil {
+			for _, src := range v.Projected.Sources {
+				if src.Secret != nil {
+					found[src.Secret.Name] = true
+				}
+			}
+		}
+	}
+
+	containers := append(append([]core.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		for _, env := range c.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				found[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+		for _, src := range c.EnvFrom {
+			if src.SecretRef != nil {
+				found[src.SecretRef.Name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CascadeDeprovision removes the bindings to a templated instance, waits until their
+// service bindings and secrets are gone, and then deletes the instance. The bindings
+// that were removed are returned, even when a later step fails.
+func (app *App) CascadeDeprovision(ns, instanceName string, timeout time.Duration,
+	progress func(binding string) WaitProgress) ([]templates.TemplatedBinding, error) {
+
+	deleted, err := app.Unbind(ns, instanceName)
+	if err != nil {
+		return deleted, err
+	}
+
+	for _, tbnd := range deleted {
+		var p WaitProgress
+		if progress != nil {
+			p = progress(tbnd.Name)
+		}
+		if err := app.WaitForTemplatedBinding(tbnd.Namespace, tbnd.Name, WaitForDeleted, timeout, p); err != nil {
+			return deleted, fmt.Errorf("binding %s/%s was not removed (%s)", tbnd.Namespace, tbnd.Name, err)
+		}
+	}
+
+	return deleted, app.Deprovision(ns, instanceName)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestPodSpecSecrets(t *testing.T) {
+	spec := core.PodSpec{
+		Volumes: []core.Volume{
+			{Name: "creds", VolumeSource: core.VolumeSource{Secret: &core.SecretVolumeSource{SecretName: "mysql"}}},
+			{Name: "config", VolumeSource: core.VolumeSource{ConfigMap: &core.ConfigMapVolumeSource{}}},
+		},
+		InitContainers: []core.Container{
+			{EnvFrom: []core.EnvFromSource{{SecretRef: &core.SecretEnvSource{LocalObjectReference: core.LocalObjectReference{Name: "redis"}}}}},
+		},
+		Containers: []core.Container{
+			{Env: []core.EnvVar{
+				{Name: "HOST", ValueFrom: &core.EnvVarSource{SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: "mysql"}, Key: "host"}}},
+				{Name: "PLAIN", Value: "value"},
+			}},
+		},
+	}
+
+	got := podSpecSecrets(spec)
+	want := []string{"mysql", "redis"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// newDeprovisionObjects returns a templated instance with two bindings, whose
+// secrets are wordpress-mysql and ghost-mysql.
+func newDeprovisionObjects() []runtime.Object {
+	binding := func(name string) *templates.TemplatedBinding {
+		return &templates.TemplatedBinding{
+			ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: templates.TemplatedBindingSpec{
+				TemplatedInstanceRef: svcat.LocalObjectReference{Name: "mydb"},
+				SecretName:           name + "-mysql",
+			},
+		}
+	}
+	return []runtime.Object{
+		newTestTemplatedInstance("mydb", "mysqldb", templates.ProviderServiceCatalog, nil),
+		newTestTemplatedInstance("unbound", "mysqldb", templates.ProviderServiceCatalog, nil),
+		binding("wordpress"),
+		binding("ghost"),
+	}
+}
+
+func TestPlanDeprovision(t *testing.T) {
+	secretEnv := func(secret string) core.PodSpec {
+		return core.PodSpec{Containers: []core.Container{{
+			EnvFrom: []core.EnvFromSource{{SecretRef: &core.SecretEnvSource{LocalObjectReference: core.LocalObjectReference{Name: secret}}}},
+		}}}
+	}
+	controlledBy := func(kind, name string) meta.ObjectMeta {
+		controller := true
+		return meta.ObjectMeta{
+			Name:            name + "-1",
+			OwnerReferences: []meta.OwnerReference{{Kind: kind, Name: name, Controller: &controller}},
+		}
+	}
+	kube := fakeKubernetes{
+		deployments: []apps.Deployment{{
+			ObjectMeta: meta.ObjectMeta{Name: "wordpress"},
+			Spec:       apps.DeploymentSpec{Template: core.PodTemplateSpec{Spec: secretEnv("wordpress-mysql")}},
+		}},
+		replicaSets: []apps.ReplicaSet{{
+			ObjectMeta: controlledBy("Deployment", "wordpress"),
+			Spec:       apps.ReplicaSetSpec{Template: core.PodTemplateSpec{Spec: secretEnv("wordpress-mysql")}},
+		}},
+		statefulSets: []apps.StatefulSet{{
+			ObjectMeta: meta.ObjectMeta{Name: "ghost"},
+			Spec:       apps.StatefulSetSpec{Template: core.PodTemplateSpec{Spec: secretEnv("ghost-mysql")}},
+		}},
+		daemonSets: []apps.DaemonSet{{
+			ObjectMeta: meta.ObjectMeta{Name: "agent"},
+			Spec:       apps.DaemonSetSpec{Template: core.PodTemplateSpec{Spec: secretEnv("agent-token")}},
+		}},
+		pods: []core.Pod{
+			{ObjectMeta: controlledBy("ReplicaSet", "wordpress-1"), Spec: secretEnv("wordpress-mysql")},
+			{ObjectMeta: controlledBy("Job", "migrate"), Spec: secretEnv("wordpress-mysql")},
+			{
+				ObjectMeta: meta.ObjectMeta{Name: "debug"},
+				Spec: core.PodSpec{Volumes: []core.Volume{{Name: "creds", VolumeSource: core.VolumeSource{
+					Projected: &core.ProjectedVolumeSource{Sources: []core.VolumeProjection{{
+						Secret: &core.SecretProjection{LocalObjectReference: core.LocalObjectReference{Name: "ghost-mysql"}},
+					}}},
+				}}}},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		instance      string
+		forbidden     map[string]bool
+		wantConsumers []string
+		wantUnscanned []string
+	}{
+		{
+			name:          "all workloads",
+			instance:      "mydb",
+			wantConsumers: []string{"deployment/wordpress", "statefulset/ghost", "pod/migrate-1", "pod/debug"},
+		},
+		{
+			name:          "deployments forbidden",
+			instance:      "mydb",
+			forbidden:     map[string]bool{"deployments": true},
+			wantConsumers: []string{"replicaset/wordpress-1", "statefulset/ghost", "pod/migrate-1", "pod/debug"},
+			wantUnscanned: []string{"deployments"},
+		},
+		{
+			name:          "pods forbidden",
+			instance:      "mydb",
+			forbidden:     map[string]bool{"pods": true},
+			wantConsumers: []string{"deployment/wordpress", "statefulset/ghost"},
+			wantUnscanned: []string{"pods"},
+		},
+		{
+			name:      "without bindings",
+			instance:  "unbound",
+			forbidden: map[string]bool{"pods": true, "deployments": true},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			kube := kube
+			kube.forbidden = tc.forbidden
+			app := newTestApp(t, nil, &kube, newDeprovisionObjects()...)
+
+			plan, err := app.PlanDeprovision("default", tc.instance)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var consumers []string
+			for _, c := range plan.Consumers {
+				consumers = append(consumers, c.Kind+"/"+c.Name)
+			}
+			if !reflect.DeepEqual(consumers, tc.wantConsumers) {
+				t.Errorf("expected the consumers %v, got %v", tc.wantConsumers, consumers)
+			}
+			if !reflect.DeepEqual(plan.Unscanned, tc.wantUnscanned) {
+				t.Errorf("expected the unscanned workloads %v, got %v", tc.wantUnscanned, plan.Unscanned)
+			}
+		})
+	}
+}
+
+func TestCascadeDeprovision(t *testing.T) {
+	leftover := core.Secret{ObjectMeta: meta.ObjectMeta{
+		Name:   "wordpress-mysql",
+		Labels: map[string]string{templates.LabelTemplatedBinding: "wordpress"},
+	}}
+
+	testcases := []struct {
+		name    string
+		secrets []core.Secret
+		wantErr string
+	}{
+		{name: "bindings removed"},
+		{name: "secret left", secrets: []core.Secret{leftover}, wantErr: "binding default/wordpress was not removed"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := newTestApp(t, nil, &fakeKubernetes{secrets: tc.secrets}, newDeprovisionObjects()...)
+
+			deleted, err := app.CascadeDeprovision("default", "mydb", 10*time.Millisecond, nil)
+			if len(deleted) != 2 {
+				t.Fatalf("expected both bindings to be removed, got %v", deleted)
+			}
+
+			_, getErr := app.Templates().TemplatedInstances("default").Get("mydb", meta.GetOptions{})
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !apierrors.IsNotFound(getErr) {
+					t.Fatalf("expected the instance to be deleted, got %v", getErr)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+			}
+			if getErr != nil {
+				t.Fatalf("expected the instance to be kept until its bindings are removed, got %v", getErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"fmt"
+	"sort"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// TreeEvents lists the events of every resource in the tree of a templated
+// resource, ordered from oldest to newest.
+func (app *App) TreeEvents(ns string, root *TreeNode) ([]core.Event, error) {
+	var events []core.Event
+	var walk func(node *TreeNode) error
+	walk = func(node *TreeNode) error {
+		nodeEvents, err := app.resourceEvents(node.Kind, ns, node.Name)
+		if err != nil {
+			return err
+		}
+		events = append(events, nodeEvents...)
+		for _, child := range node.Children {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, err
+	}
+
+	sortEvents(events)
+	return events, nil
+}
+
+// resourceEvents lists the events about a resource, ordered from oldest to newest.
+func (app *App) resourceEvents(kind, ns, name string) ([]core.Event, error) {
+	selector := fields.Set{
+		"involvedObject.kind": kind,
+		"involvedObject.name": name,
+	}.AsSelector().String()
+	result, err := app.CoreClient.CoreV1().Events(ns).List(meta.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the events of %s %s/%s (%s)", kind, ns, name, err)
+	}
+
+	sortEvents(result.Items)
+	return result.Items, nil
+}
+
+func sortEvents(events []core.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+	})
+}
@@ -0,0 +1,223 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"errors"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	appsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	svcatv1beta1 "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
+	svcatapp "github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+func newTestTemplatedInstance(name, serviceType string, provider templates.Provider, labels map[string]string,
+	conditions ...templates.TemplatedCondition) *templates.TemplatedInstance {
+	return &templates.TemplatedInstance{
+		ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec:       templates.TemplatedInstanceSpec{ServiceType: serviceType, Provider: provider},
+		Status:     templates.TemplatedInstanceStatus{Conditions: conditions},
+	}
+}
+
+// newTestApp builds an app on the offline templates SDK, with fake service catalog
+// and core clients. The service catalog and core clientsets do not have fakes in this tree.
+func newTestApp(t *testing.T, catalog *fakeServiceCatalog, kube *fakeKubernetes, objects ...runtime.Object) *App {
+	sdk, err := servicecatalogtempltesdk.NewOffline(objects...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if catalog == nil {
+		catalog = &fakeServiceCatalog{}
+	}
+	if kube == nil {
+		kube = &fakeKubernetes{}
+	}
+	return &App{
+		ServiceCatalogApp: &svcatapp.App{SDK: &servicecatalog.SDK{ServiceCatalogClient: catalog}},
+		SDK:               sdk,
+		CoreClient:        kube,
+	}
+}
+
+// fakeServiceCatalog serves the service instances and service bindings that the waits read.
+type fakeServiceCatalog struct {
+	clientset.Interface
+	svcatv1beta1.ServicecatalogV1beta1Interface
+
+	instances map[string]*svcat.ServiceInstance
+	bindings  map[string]*svcat.ServiceBinding
+}
+
+func (f *fakeServiceCatalog) ServicecatalogV1beta1() svcatv1beta1.ServicecatalogV1beta1Interface {
+	return f
+}
+
+func (f *fakeServiceCatalog) ServiceInstances(ns string) svcatv1beta1.ServiceInstanceInterface {
+	return fakeServiceInstances{catalog: f}
+}
+
+func (f *fakeServiceCatalog) ServiceBindings(ns string) svcatv1beta1.ServiceBindingInterface {
+	return fakeServiceBindings{catalog: f}
+}
+
+type fakeServiceInstances struct {
+	svcatv1beta1.ServiceInstanceInterface
+	catalog *fakeServiceCatalog
+}
+
+func (f fakeServiceInstances) Get(name string, opts meta.GetOptions) (*svcat.ServiceInstance, error) {
+	inst, ok := f.catalog.instances[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: svcat.GroupName, Resource: "serviceinstances"}, name)
+	}
+	return inst, nil
+}
+
+type fakeServiceBindings struct {
+	svcatv1beta1.ServiceBindingInterface
+	catalog *fakeServiceCatalog
+}
+
+func (f fakeServiceBindings) Get(name string, opts meta.GetOptions) (*svcat.ServiceBinding, error) {
+	bnd, ok := f.catalog.bindings[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: svcat.GroupName, Resource: "servicebindings"}, name)
+	}
+	return bnd, nil
+}
+
+// fakeKubernetes lists the workloads and secrets of a namespace. Listing the
+// resources in forbidden fails like a user without access to them.
+type fakeKubernetes struct {
+	kubernetes.Interface
+
+	pods         []core.Pod
+	secrets      []core.Secret
+	deployments  []apps.Deployment
+	replicaSets  []apps.ReplicaSet
+	statefulSets []apps.StatefulSet
+	daemonSets   []apps.DaemonSet
+	forbidden    map[string]bool
+}
+
+func (f *fakeKubernetes) list(resource string) error {
+	if f.forbidden[resource] {
+		return apierrors.NewForbidden(schema.GroupResource{Resource: resource}, "", errors.New("access denied"))
+	}
+	return nil
+}
+
+func (f *fakeKubernetes) CoreV1() corev1.CoreV1Interface {
+	return fakeCoreV1{kube: f}
+}
+
+func (f *fakeKubernetes) AppsV1() appsv1.AppsV1Interface {
+	return fakeAppsV1{kube: f}
+}
+
+type fakeCoreV1 struct {
+	corev1.CoreV1Interface
+	kube *fakeKubernetes
+}
+
+func (f fakeCoreV1) Pods(ns string) corev1.PodInterface       { return fakePods{kube: f.kube} }
+func (f fakeCoreV1) Secrets(ns string) corev1.SecretInterface { return fakeSecrets{kube: f.kube} }
+
+type fakePods struct {
+	corev1.PodInterface
+	kube *fakeKubernetes
+}
+
+func (f fakePods) List(opts meta.ListOptions) (*core.PodList, error) {
+	return &core.PodList{Items: f.kube.pods}, f.kube.list("pods")
+}
+
+type fakeSecrets struct {
+	corev1.SecretInterface
+	kube *fakeKubernetes
+}
+
+func (f fakeSecrets) List(opts meta.ListOptions) (*core.SecretList, error) {
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	result := &core.SecretList{}
+	for _, s := range f.kube.secrets {
+		if selector.Matches(labels.Set(s.Labels)) {
+			result.Items = append(result.Items, s)
+		}
+	}
+	return result, f.kube.list("secrets")
+}
+
+type fakeAppsV1 struct {
+	appsv1.AppsV1Interface
+	kube *fakeKubernetes
+}
+
+func (f fakeAppsV1) Deployments(ns string) appsv1.DeploymentInterface {
+	return fakeDeployments{kube: f.kube}
+}
+func (f fakeAppsV1) ReplicaSets(ns string) appsv1.ReplicaSetInterface {
+	return fakeReplicaSets{kube: f.kube}
+}
+func (f fakeAppsV1) StatefulSets(ns string) appsv1.StatefulSetInterface {
+	return fakeStatefulSets{kube: f.kube}
+}
+func (f fakeAppsV1) DaemonSets(ns string) appsv1.DaemonSetInterface {
+	return fakeDaemonSets{kube: f.kube}
+}
+
+type fakeDeployments struct {
+	appsv1.DeploymentInterface
+	kube *fakeKubernetes
+}
+
+func (f fakeDeployments) List(opts meta.ListOptions) (*apps.DeploymentList, error) {
+	return &apps.DeploymentList{Items: f.kube.deployments}, f.kube.list("deployments")
+}
+
+type fakeReplicaSets struct {
+	appsv1.ReplicaSetInterface
+	kube *fakeKubernetes
+}
+
+func (f fakeReplicaSets) List(opts meta.ListOptions) (*apps.ReplicaSetList, error) {
+	return &apps.ReplicaSetList{Items: f.kube.replicaSets}, f.kube.list("replicasets")
+}
+
+type fakeStatefulSets struct {
+	appsv1.StatefulSetInterface
+	kube *fakeKubernetes
+}
+
+func (f fakeStatefulSets) List(opts meta.ListOptions) (*apps.StatefulSetList, error) {
+	return &apps.StatefulSetList{Items: f.kube.statefulSets}, f.kube.list("statefulsets")
+}
+
+type fakeDaemonSets struct {
+	appsv1.DaemonSetInterface
+	kube *fakeKubernetes
+}
+
+func (f fakeDaemonSets) List(opts meta.ListOptions) (*apps.DaemonSetList, error) {
+	return &apps.DaemonSetList{Items: f.kube.daemonSets}, f.kube.list("daemonsets")
+}
@@ -0,0 +1,124 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	templatesscheme "github.com/Azure/service-catalog-templates/pkg/client/clientset/versioned/scheme"
+)
+
+// LoadManifests reads the templates and templated resources defined in the
+// specified files and directories. Directories are searched recursively for
+// .yaml, .yml and .json files, and resources of any other kind are ignored.
+// Namespaced resources that do not specify a namespace are placed in defaultNamespace.
+func LoadManifests(paths []string, defaultNamespace string) ([]runtime.Object, error) {
+	var objects []runtime.Object
+	for _, path := range paths {
+		files, err := findManifests(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			fileObjects, err := loadManifest(file, defaultNamespace)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, fileObjects...)
+		}
+	}
+	return objects, nil
+}
+
+func findManifests(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s (%s)", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(file) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to search %s for manifests (%s)", path, err)
+	}
+	return files, nil
+}
+
+func loadManifest(file string, defaultNamespace string) ([]runtime.Object, error) {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s (%s)", file, err)
+	}
+
+	var objects []runtime.Object
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(contents), 4096)
+	deserializer := templatesscheme.Codecs.UniversalDeserializer()
+	for {
+		var doc runtime.RawExtension
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("unable to parse %s (%s)", file, err)
+		}
+		if len(bytes.TrimSpace(doc.Raw)) == 0 || string(doc.Raw) == "null" {
+			continue
+		}
+
+		obj, _, err := deserializer.Decode(doc.Raw, nil, nil)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+				// Skip resources that are not templates, e.g. the deployment for the application
+				continue
+			}
+			return nil, fmt.Errorf("unable to decode a resource in %s (%s)", file, err)
+		}
+
+		if isNamespaced(obj) {
+			accessor, err := meta.Accessor(obj)
+			if err != nil {
+				return nil, err
+			}
+			if accessor.GetNamespace() == "" {
+				accessor.SetNamespace(defaultNamespace)
+			}
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+func isNamespaced(obj runtime.Object) bool {
+	switch obj.(type) {
+	case *templates.TemplatedInstance, *templates.TemplatedBinding, *templates.InstanceTemplate, *templates.BindingTemplate:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+func TestLoadManifests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "svcatt-manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"app.yaml": `
+---
+apiVersion: templates.servicecatalog.k8s.io/experimental
+kind: TemplatedInstance
+metadata:
+  name: wordpress-db
+spec:
+  serviceType: mysqldb
+---
+---
+null
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: wordpress
+---
+apiVersion: templates.servicecatalog.k8s.io/experimental
+kind: TemplatedBinding
+metadata:
+  name: wordpress-db
+  namespace: prod
+spec:
+  instanceRef:
+    name: wordpress-db
+`,
+		"templates/cluster.yml": `
+apiVersion: templates.servicecatalog.k8s.io/experimental
+kind: ClusterInstanceTemplate
+metadata:
+  name: mysqldb
+spec:
+  serviceType: mysqldb
+---
+apiVersion: templates.servicecatalog.k8s.io/experimental
+kind: BrokerInstanceTemplate
+metadata:
+  name: azure-mysqldb
+spec:
+  brokerName: azure
+  serviceType: mysqldb
+`,
+		"templates/team/mysql.json": `{
+  "apiVersion": "templates.servicecatalog.k8s.io/experimental",
+  "kind": "InstanceTemplate",
+  "metadata": {"name": "mysqldb"},
+  "spec": {"serviceType": "mysqldb"}
+}`,
+		"templates/README.md":  "# Not a manifest: {",
+		"templates/values.txt": "serviceType: [",
+	}
+	for name, contents := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testcases := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name:  "multiple documents",
+			paths: []string{filepath.Join(dir, "app.yaml")},
+			want:  []string{"TemplatedInstance dev/wordpress-db", "TemplatedBinding prod/wordpress-db"},
+		},
+		{
+			name:  "cluster templates",
+			paths: []string{filepath.Join(dir, "templates", "cluster.yml")},
+			want:  []string{"ClusterInstanceTemplate mysqldb", "BrokerInstanceTemplate azure-mysqldb"},
+		},
+		{
+			name:  "directory",
+			paths: []string{dir},
+			want: []string{
+				"TemplatedInstance dev/wordpress-db",
+				"TemplatedBinding prod/wordpress-db",
+				"ClusterInstanceTemplate mysqldb",
+				"BrokerInstanceTemplate azure-mysqldb",
+				"InstanceTemplate dev/mysqldb",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			objects, err := LoadManifests(tc.paths, "dev")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, obj := range objects {
+				accessor, err := meta.Accessor(obj)
+				if err != nil {
+					t.Fatal(err)
+				}
+				name := accessor.GetName()
+				if accessor.GetNamespace() != "" {
+					name = accessor.GetNamespace() + "/" + name
+				}
+				got = append(got, objectKind(obj)+" "+name)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := LoadManifests([]string{filepath.Join(dir, "missing.yaml")}, "dev"); err == nil {
+		t.Fatal("expected a missing file to fail")
+	}
+}
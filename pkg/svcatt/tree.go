@@ -0,0 +1,238 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// Statuses of tree nodes whose resource could not be used.
+const (
+	// NodeMissing is the status of a resource that does not exist.
+	NodeMissing = "Missing"
+
+	// NodeUnmanaged is the status of a resource that is not controlled by its parent.
+	NodeUnmanaged = "Unmanaged"
+)
+
+// TreeNode is a resource in the ownership tree of a templated resource.
+type TreeNode struct {
+	Kind      string      `json:"kind"`
+	Name      string      `json:"name"`
+	Status    string      `json:"status"`
+	LastEvent string      `json:"lastEvent,omitempty"`
+	Templates []string    `json:"templates,omitempty"`
+	Children  []*TreeNode `json:"children,omitempty"`
+}
+
+// TemplatedInstanceTree builds the tree of resources managed for a templated instance:
+// its service instance, or deployment and service, and the trees of its bindings.
+func (app *App) TemplatedInstanceTree(ns, name string) (*TreeNode, error) {
+	tinst, err := app.RetrieveTemplatedInstance(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	node := app.newNode(templates.InstanceKind, tinst, builder.GetStatus(tinst.Status.Conditions))
+	if sources, err := app.InstanceTemplateSources(tinst); err == nil {
+		for _, s := range sources {
+			node.Templates = append(node.Templates, s.String())
+		}
+	}
+
+	if builder.UsesContainerProvider(tinst) {
+		node.Children = append(node.Children, app.deploymentNode(tinst), app.serviceNode(tinst))
+	} else {
+		node.Children = append(node.Children, app.serviceInstanceNode(tinst))
+	}
+
+	bindings, err := app.RetrieveTemplatedBindingsByInstance(tinst)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bindings {
+		node.Children = append(node.Children, app.templatedBindingNode(&bindings[i]))
+	}
+
+	return node, nil
+}
+
+// TemplatedBindingTree builds the tree of resources managed for a templated binding:
+// its service binding, the secret that service catalog creates for it, and the secret
+// projected from that secret.
+func (app *App) TemplatedBindingTree(ns, name string) (*TreeNode, error) {
+	tbnd, err := app.RetrieveTemplatedBinding(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return app.templatedBindingNode(tbnd), nil
+}
+
+func (app *App) templatedBindingNode(tbnd *templates.TemplatedBinding) *TreeNode {
+	node := app.newNode(templates.BindingKind, tbnd, builder.GetStatus(tbnd.Status.Conditions))
+	if sources, err := app.BindingTemplateSources(tbnd); err == nil {
+		for _, s := range sources {
+			node.Templates = append(node.Templates, s.String())
+		}
+	}
+
+	// Bindings to a container provided instance have no service binding,
+	// the templates controller creates the secret for them.
+	parent := node
+	tinst, err := app.Templates().TemplatedInstances(tbnd.Namespace).Get(tbnd.Spec.TemplatedInstanceRef.Name, meta.GetOptions{})
+	if err != nil || !builder.UsesContainerProvider(tinst) {
+		parent = app.serviceBindingNode(tbnd)
+		node.Children = append(node.Children, parent)
+	}
+
+	if tbnd.Spec.SecretName == "" {
+		return node
+	}
+
+	shadowName := builder.ShadowSecretName(tbnd.Spec.SecretName)
+	shadow, err := app.CoreClient.CoreV1().Secrets(tbnd.Namespace).Get(shadowName, meta.GetOptions{})
+	if err != nil {
+		parent.Children = append(parent.Children, app.missingNode("Secret", tbnd.Namespace, shadowName, err))
+		return node
+	}
+	shadowNode := app.newNode("Secret", shadow, secretStatus(shadow))
+	parent.Children = append(parent.Children, shadowNode)
+
+	projected, err := app.CoreClient.CoreV1().Secrets(tbnd.Namespace).Get(tbnd.Spec.SecretName, meta.GetOptions{})
+	if err != nil {
+		shadowNode.Children = append(shadowNode.Children, app.missingNode("Secret", tbnd.Namespace, tbnd.Spec.SecretName, err))
+		return node
+	}
+	status := secretStatus(projected)
+	if !meta.IsControlledBy(projected, shadow) {
+		status = NodeUnmanaged
+	}
+	shadowNode.Children = append(shadowNode.Children, app.newNode("Secret", projected, status))
+
+	return node
+}
+
+func (app *App) serviceInstanceNode(tinst *templates.TemplatedInstance) *TreeNode {
+	inst, err := app.ServiceCatalog().ServiceInstances(tinst.Namespace).Get(tinst.Name, meta.GetOptions{})
+	if err != nil {
+		return app.missingNode("ServiceInstance", tinst.Namespace, tinst.Name, err)
+	}
+	if !meta.IsControlledBy(inst, tinst) {
+		return app.newNode("ServiceInstance", inst, NodeUnmanaged)
+	}
+
+	status := builder.StatusPending
+	if failed, _ := builder.GetInstanceFailure(inst); failed {
+		status = builder.StatusFailed
+	} else {
+		for _, c := range inst.Status.Conditions {
+			if c.Type == svcat.ServiceInstanceConditionReady {
+				status = readyStatus(c.Status, c.Reason)
+			}
+		}
+	}
+	return app.newNode("ServiceInstance", inst, status)
+}
+
+func (app *App) serviceBindingNode(tbnd *templates.TemplatedBinding) *TreeNode {
+	bnd, err := app.ServiceCatalog().ServiceBindings(tbnd.Namespace).Get(tbnd.Name, meta.GetOptions{})
+	if err != nil {
+		return app.missingNode("ServiceBinding", tbnd.Namespace, tbnd.Name, err)
+	}
+	if !meta.IsControlledBy(bnd, tbnd) {
+		return app.newNode("ServiceBinding", bnd, NodeUnmanaged)
+	}
+
+	status := builder.StatusPending
+	if failed, _ := builder.GetBindingFailure(bnd); failed {
+		status = builder.StatusFailed
+	} else {
+		for _, c := range bnd.Status.Conditions {
+			if c.Type == svcat.ServiceBindingConditionReady {
+				status = readyStatus(c.Status, c.Reason)
+			}
+		}
+	}
+	return app.newNode("ServiceBinding", bnd, status)
+}
+
+func (app *App) deploymentNode(tinst *templates.TemplatedInstance) *TreeNode {
+	deployment, err := app.CoreClient.AppsV1().Deployments(tinst.Namespace).Get(tinst.Name, meta.GetOptions{})
+	if err != nil {
+		return app.missingNode("Deployment", tinst.Namespace, tinst.Name, err)
+	}
+	if !meta.IsControlledBy(deployment, tinst) {
+		return app.newNode("Deployment", deployment, NodeUnmanaged)
+	}
+
+	status := fmt.Sprintf("%d/%d available", deployment.Status.AvailableReplicas, deployment.Status.Replicas)
+	return app.newNode("Deployment", deployment, status)
+}
+
+func (app *App) serviceNode(tinst *templates.TemplatedInstance) *TreeNode {
+	service, err := app.CoreClient.CoreV1().Services(tinst.Namespace).Get(tinst.Name, meta.GetOptions{})
+	if err != nil {
+		return app.missingNode("Service", tinst.Namespace, tinst.Name, err)
+	}
+	if !meta.IsControlledBy(service, tinst) {
+		return app.newNode("Service", service, NodeUnmanaged)
+	}
+	return app.newNode("Service", service, service.Spec.ClusterIP)
+}
+
+func (app *App) newNode(kind string, obj meta.Object, status string) *TreeNode {
+	return &TreeNode{
+		Kind:      kind,
+		Name:      obj.GetName(),
+		Status:    status,
+		LastEvent: app.lastEvent(kind, obj.GetNamespace(), obj.GetName()),
+	}
+}
+
+// missingNode reports a resource that could not be retrieved.
+func (app *App) missingNode(kind, ns, name string, err error) *TreeNode {
+	status := NodeMissing
+	if !apierrors.IsNotFound(err) {
+		status = fmt.Sprintf("Unknown (%s)", err)
+	}
+	return &TreeNode{
+		Kind:      kind,
+		Name:      name,
+		Status:    status,
+		LastEvent: app.lastEvent(kind, ns, name),
+	}
+}
+
+// lastEvent formats the most recent event about a resource. Failing to read the
+// events leaves it empty, since they are only a hint.
+func (app *App) lastEvent(kind, ns, name string) string {
+	events, err := app.resourceEvents(kind, ns, name)
+	if err != nil || len(events) == 0 {
+		return ""
+	}
+	last := events[len(events)-1]
+	return fmt.Sprintf("%s: %s", last.Reason, last.Message)
+}
+
+func readyStatus(status svcat.ConditionStatus, reason string) string {
+	if status == svcat.ConditionTrue {
+		return builder.StatusReady
+	}
+	if reason == "" {
+		return builder.StatusPending
+	}
+	return reason
+}
+
+func secretStatus(secret *core.Secret) string {
+	return fmt.Sprintf("%d keys", len(secret.Data))
+}
@@ -0,0 +1,267 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// WaitCondition is the state of a templated resource that a wait blocks until.
+type WaitCondition string
+
+const (
+	// WaitForReady waits until the templated resource and the resources that it manages are ready.
+	WaitForReady WaitCondition = "ready"
+
+	// WaitForDeleted waits until the templated resource and the resources that it manages are removed.
+	WaitForDeleted WaitCondition = "deleted"
+)
+
+// WaitInterval is how often the resources are polled while waiting.
+const WaitInterval = 2 * time.Second
+
+// WaitProgress is called with the status of the resource being waited on, whenever it changes.
+type WaitProgress func(status string)
+
+// ParseWaitCondition parses the value of the --for flag.
+func ParseWaitCondition(value string) (WaitCondition, error) {
+	switch WaitCondition(value) {
+	case WaitForReady, WaitForDeleted:
+		return WaitCondition(value), nil
+	case "delete":
+		return WaitForDeleted, nil
+	default:
+		return "", fmt.Errorf("invalid condition %q, allowed conditions are: ready, deleted", value)
+	}
+}
+
+// waitCheck reports the status of a resource, and if the wait is done.
+type waitCheck func() (status string, done bool, err error)
+
+// WaitForTemplatedInstance polls a templated instance, and the service instance or
+// deployment that it manages, until it reaches the condition or the timeout expires.
+func (app *App) WaitForTemplatedInstance(ns, name string, condition WaitCondition, timeout time.Duration, progress WaitProgress) error {
+	check := func() (string, bool, error) { return app.templatedInstanceReady(ns, name) }
+	if condition == WaitForDeleted {
+		check = func() (string, bool, error) { return app.templatedInstanceDeleted(ns, name) }
+	}
+	return poll(timeout, progress, check)
+}
+
+// WaitForTemplatedBinding polls a templated binding, its service binding and the
+// projected secret, until it reaches the condition or the timeout expires.
+func (app *App) WaitForTemplatedBinding(ns, name string, condition WaitCondition, timeout time.Duration, progress WaitProgress) error {
+	check := func() (string, bool, error) { return app.templatedBindingReady(ns, name) }
+	if condition == WaitForDeleted {
+		check = func() (string, bool, error) { return app.templatedBindingDeleted(ns, name) }
+	}
+	return poll(timeout, progress, check)
+}
+
+func poll(timeout time.Duration, progress WaitProgress, check waitCheck) error {
+	last := ""
+	err := wait.PollImmediate(WaitInterval, timeout, func() (bool, error) {
+		status, done, err := check()
+		if err != nil {
+			return false, err
+		}
+		if status != last {
+			last = status
+			if progress != nil {
+				progress(status)
+			}
+		}
+		return done, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("timed out after %s, %s", timeout, last)
+	}
+	return err
+}
+
+func (app *App) templatedInstanceReady(ns, name string) (string, bool, error) {
+	tinst, err := app.Templates().TemplatedInstances(ns).Get(name, meta.GetOptions{})
+	if err != nil {
+		return "", false, fmt.Errorf("unable to get templated instance %s/%s (%s)", ns, name, err)
+	}
+	if err := templatedFailure(tinst.Status.Conditions); err != nil {
+		return "", false, err
+	}
+
+	if tinst.Spec.Provider == "" && !builder.WasProvisioned(tinst.Status.Conditions) {
+		return "waiting for the templated instance to be resolved", false, nil
+	}
+
+	if builder.UsesContainerProvider(tinst) {
+		if isTemplatedReady(tinst.Status.Conditions) {
+			return "ready", true, nil
+		}
+		return "waiting for the deployment to be available", false, nil
+	}
+
+	inst, err := app.GetManagedServiceInstance(tinst)
+	if apierrors.IsNotFound(err) {
+		return "waiting for the service instance to be created", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	for _, c := range inst.Status.Conditions {
+		if c.Type == svcat.ServiceInstanceConditionFailed && c.Status == svcat.ConditionTrue {
+			return "", false, fmt.Errorf("service instance %s/%s failed: %s", ns, name, c.Message)
+		}
+	}
+	for _, c := range inst.Status.Conditions {
+		if c.Type == svcat.ServiceInstanceConditionReady && c.Status != svcat.ConditionTrue {
+			return fmt.Sprintf("waiting for the service instance: %s", c.Message), false, nil
+		}
+	}
+	if !isTemplatedReady(tinst.Status.Conditions) {
+		return "waiting for the service instance to be ready", false, nil
+	}
+	return "ready", true, nil
+}
+
+func (app *App) templatedInstanceDeleted(ns, name string) (string, bool, error) {
+	_, err := app.Templates().TemplatedInstances(ns).Get(name, meta.GetOptions{})
+	if err == nil {
+		return "waiting for the templated instance to be deleted", false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return "", false, err
+	}
+
+	// The service instance is garbage collected once the templated instance is removed
+	inst, err := app.ServiceCatalog().ServiceInstances(ns).Get(name, meta.GetOptions{})
+	if err == nil && isManagedBy(inst.OwnerReferences, templates.InstanceKind) {
+		return "waiting for the service instance to be deprovisioned", false, nil
+	} else if err != nil && !apierrors.IsNotFound(err) {
+		return "", false, err
+	}
+	return "deleted", true, nil
+}
+
+func (app *App) templatedBindingReady(ns, name string) (string, bool, error) {
+	tbnd, err := app.Templates().TemplatedBindings(ns).Get(name, meta.GetOptions{})
+	if err != nil {
+		return "", false, fmt.Errorf("unable to get templated binding %s/%s (%s)", ns, name, err)
+	}
+	if err := templatedFailure(tbnd.Status.Conditions); err != nil {
+		return "", false, err
+	}
+
+	tinst, err := app.Templates().TemplatedInstances(ns).Get(tbnd.Spec.TemplatedInstanceRef.Name, meta.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("waiting for templated instance %s to be created", tbnd.Spec.TemplatedInstanceRef.Name), false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	if tinst.Spec.Provider == "" && !builder.WasProvisioned(tinst.Status.Conditions) {
+		return fmt.Sprintf("waiting for templated instance %s to be resolved", tinst.Name), false, nil
+	}
+
+	if !builder.UsesContainerProvider(tinst) {
+		bnd, err := app.GetManagedServiceBinding(tbnd)
+		if apierrors.IsNotFound(err) {
+			return "waiting for the service binding to be created", false, nil
+		} else if err != nil {
+			return "", false, err
+		}
+
+		for _, c := range bnd.Status.Conditions {
+			if c.Type == svcat.ServiceBindingConditionFailed && c.Status == svcat.ConditionTrue {
+				return "", false, fmt.Errorf("service binding %s/%s failed: %s", ns, name, c.Message)
+			}
+		}
+		for _, c := range bnd.Status.Conditions {
+			if c.Type == svcat.ServiceBindingConditionReady && c.Status != svcat.ConditionTrue {
+				return fmt.Sprintf("waiting for the service binding: %s", c.Message), false, nil
+			}
+		}
+	}
+
+	if tbnd.Spec.SecretName == "" {
+		return "waiting for the templated binding to be resolved", false, nil
+	}
+	_, err = app.CoreClient.CoreV1().Secrets(ns).Get(tbnd.Spec.SecretName, meta.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("waiting for secret %s to be projected", tbnd.Spec.SecretName), false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	if !isTemplatedReady(tbnd.Status.Conditions) {
+		return "waiting for the templated binding to be ready", false, nil
+	}
+	return "ready", true, nil
+}
+
+func (app *App) templatedBindingDeleted(ns, name string) (string, bool, error) {
+	_, err := app.Templates().TemplatedBindings(ns).Get(name, meta.GetOptions{})
+	if err == nil {
+		return "waiting for the templated binding to be deleted", false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return "", false, err
+	}
+
+	// The service binding and secrets are garbage collected once the templated binding is removed
+	bnd, err := app.ServiceCatalog().ServiceBindings(ns).Get(name, meta.GetOptions{})
+	if err == nil && isManagedBy(bnd.OwnerReferences, templates.BindingKind) {
+		return "waiting for the service binding to be unbound", false, nil
+	} else if err != nil && !apierrors.IsNotFound(err) {
+		return "", false, err
+	}
+
+	secrets, err := app.CoreClient.CoreV1().Secrets(ns).List(meta.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", templates.LabelTemplatedBinding, name),
+	})
+	if err != nil {
+		return "", false, err
+	}
+	if len(secrets.Items) > 0 {
+		return fmt.Sprintf("waiting for secret %s to be deleted", secrets.Items[0].Name), false, nil
+	}
+	return "deleted", true, nil
+}
+
+// templatedFailure returns the error reported on the conditions of a templated
+// resource, when it will not be resolved by waiting. A managed resource that was
+// deleted is not recreated under the Report drift policy.
+func templatedFailure(conditions []templates.TemplatedCondition) error {
+	for _, c := range conditions {
+		if c.Status != svcat.ConditionFalse {
+			continue
+		}
+		switch errors.Reason(c.Reason) {
+		case errors.ReasonTemplateNotFound, errors.ReasonAmbiguousBroker, errors.ReasonInvalidParameters,
+			errors.ReasonBrokerFailure, errors.ReasonUnmanagedResource, builder.ReasonManagedResourceDeleted:
+			return fmt.Errorf("%s: %s", c.Reason, c.Message)
+		}
+	}
+	return nil
+}
+
+func isTemplatedReady(conditions []templates.TemplatedCondition) bool {
+	c := builder.GetCondition(conditions, templates.TemplatedConditionReady)
+	return c != nil && c.Status == svcat.ConditionTrue
+}
+
+func isManagedBy(owners []meta.OwnerReference, kind string) bool {
+	for _, owner := range owners {
+		if owner.Controller != nil && *owner.Controller && owner.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
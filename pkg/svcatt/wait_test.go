@@ -0,0 +1,75 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package svcatt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templates "github.com/Azure/service-catalog-templates/pkg/apis/templates/experimental"
+	sdkerrors "github.com/Azure/service-catalog-templates/pkg/service-catalog-templates-sdk/errors"
+	"github.com/Azure/service-catalog-templates/pkg/service-catalog-templates/builder"
+	svcat "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestWaitForTemplatedInstance(t *testing.T) {
+	ready := builder.SetCondition(nil, templates.TemplatedConditionReady, svcat.ConditionTrue, builder.ReasonDeploymentAvailable, "")
+	brokerFailure := builder.SetCondition(nil, templates.TemplatedConditionReady, svcat.ConditionFalse, string(sdkerrors.ReasonBrokerFailure), "quota exceeded")
+	deleted := builder.SetDeletedStatus(nil, "service instance")
+
+	managed := &svcat.ServiceInstance{
+		ObjectMeta: meta.ObjectMeta{
+			Name: "deprovisioning",
+			OwnerReferences: []meta.OwnerReference{
+				*meta.NewControllerRef(newTestTemplatedInstance("deprovisioning", "mysqldb", "", nil), templates.SchemeGroupVersion.WithKind(templates.InstanceKind)),
+			},
+		},
+	}
+
+	catalog := &fakeServiceCatalog{instances: map[string]*svcat.ServiceInstance{"deprovisioning": managed}}
+	app := newTestApp(t, catalog, nil,
+		newTestTemplatedInstance("ready", "mysqldb", templates.ProviderContainer, nil, ready...),
+		newTestTemplatedInstance("unresolved", "mysqldb", "", nil),
+		newTestTemplatedInstance("broker-failure", "mysqldb", templates.ProviderServiceCatalog, nil, brokerFailure...),
+		newTestTemplatedInstance("deleted", "mysqldb", templates.ProviderServiceCatalog, nil, deleted...),
+	)
+
+	testcases := []struct {
+		name      string
+		condition WaitCondition
+		wantErr   string
+	}{
+		{name: "ready", condition: WaitForReady},
+		{name: "unresolved", condition: WaitForReady, wantErr: "timed out after 10ms, waiting for the templated instance to be resolved"},
+		{name: "broker-failure", condition: WaitForReady, wantErr: "BrokerFailure: quota exceeded"},
+		{name: "deleted", condition: WaitForReady, wantErr: builder.ReasonManagedResourceDeleted},
+		{name: "gone", condition: WaitForDeleted},
+		{name: "deprovisioning", condition: WaitForDeleted, wantErr: "waiting for the service instance to be deprovisioned"},
+		{name: "ready", condition: WaitForDeleted, wantErr: "waiting for the templated instance to be deleted"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name+"/"+string(tc.condition), func(t *testing.T) {
+			var statuses []string
+			err := app.WaitForTemplatedInstance("default", tc.name, tc.condition, 10*time.Millisecond,
+				func(status string) { statuses = append(statuses, status) })
+
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(statuses) == 0 {
+					t.Fatal("expected the progress to be reported")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}